@@ -1,41 +1,236 @@
 package config
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// Config is the effective application configuration. Every setting has a
+// key (its path in the config file, also used as the CLI flag name), an
+// environment variable and a default. Sources are applied in this order,
+// each overriding the previous one: defaults, the config file, environment
+// variables and finally CLI flags.
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	JWTSecret  string
-	AppPort    string
+	App       AppConfig       `yaml:"app" toml:"app"`
+	Server    ServerConfig    `yaml:"server" toml:"server"`
+	Database  DatabaseConfig  `yaml:"database" toml:"database"`
+	Auth      AuthConfig      `yaml:"auth" toml:"auth"`
+	CORS      CORSConfig      `yaml:"cors" toml:"cors"`
+	RateLimit RateLimitConfig `yaml:"rate_limit" toml:"rate_limit"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Metrics   MetricsConfig   `yaml:"metrics" toml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
+	Trash     TrashConfig     `yaml:"trash" toml:"trash"`
+	Report    ReportConfig    `yaml:"report" toml:"report"`
+	Mail      MailConfig      `yaml:"mail" toml:"mail"`
+	Import    ImportConfig    `yaml:"import" toml:"import"`
+}
+
+type AppConfig struct {
+	Env string `yaml:"env" toml:"env" env:"APP_ENV"`
+}
+
+type ServerConfig struct {
+	Port              int       `yaml:"port" toml:"port" env:"APP_PORT"`
+	ReadTimeout       Duration  `yaml:"read_timeout" toml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout Duration  `yaml:"read_header_timeout" toml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      Duration  `yaml:"write_timeout" toml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       Duration  `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int       `yaml:"max_header_bytes" toml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	ShutdownTimeout   Duration  `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	TLS               TLSConfig `yaml:"tls" toml:"tls"`
+}
+
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled" toml:"enabled" env:"TLS_ENABLED"`
+	CertFile string `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE"`
+}
+
+type DatabaseConfig struct {
+	Driver          string   `yaml:"driver" toml:"driver" env:"DB_DRIVER"`
+	Path            string   `yaml:"path" toml:"path" env:"DB_PATH"`
+	Host            string   `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port            int      `yaml:"port" toml:"port" env:"DB_PORT"`
+	User            string   `yaml:"user" toml:"user" env:"DB_USER"`
+	Password        string   `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string   `yaml:"name" toml:"name" env:"DB_NAME"`
+	SSLMode         string   `yaml:"ssl_mode" toml:"ssl_mode" env:"DB_SSL_MODE"`
+	TimeZone        string   `yaml:"time_zone" toml:"time_zone" env:"DB_TIME_ZONE"`
+	MaxOpenConns    int      `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int      `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	JWTKeys   string `yaml:"jwt_keys" toml:"jwt_keys" env:"JWT_KEYS"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	AllowCredentials bool     `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           Duration `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+type RateLimitConfig struct {
+	Enabled           bool    `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	RequestsPerSecond float64 `yaml:"requests_per_second" toml:"requests_per_second" env:"RATE_LIMIT_RPS"`
+	Burst             int     `yaml:"burst" toml:"burst" env:"RATE_LIMIT_BURST"`
+}
+
+type LogConfig struct {
+	Level              string   `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	SlowQueryThreshold Duration `yaml:"slow_query_threshold" toml:"slow_query_threshold" env:"LOG_SLOW_QUERY_THRESHOLD"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Token   string `yaml:"token" toml:"token" env:"METRICS_TOKEN" secret:"true"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint" env:"TRACING_ENDPOINT"`
+	ServiceName string  `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type TrashConfig struct {
+	RetentionDays int `yaml:"retention_days" toml:"retention_days" env:"TRASH_RETENTION_DAYS"`
 }
 
-func Load() *Config {
+type ReportConfig struct {
+	TemplatesDir string `yaml:"templates_dir" toml:"templates_dir" env:"REPORT_TEMPLATES_DIR"`
+}
+
+type ImportConfig struct {
+	MaxBytes int `yaml:"max_bytes" toml:"max_bytes" env:"IMPORT_MAX_BYTES"`
+}
+
+type MailConfig struct {
+	Driver   string `yaml:"driver" toml:"driver" env:"MAIL_DRIVER"`
+	Host     string `yaml:"host" toml:"host" env:"MAIL_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"MAIL_PORT"`
+	Username string `yaml:"username" toml:"username" env:"MAIL_USERNAME"`
+	Password string `yaml:"password" toml:"password" env:"MAIL_PASSWORD" secret:"true"`
+	From     string `yaml:"from" toml:"from" env:"MAIL_FROM"`
+}
+
+// Default returns the configuration used when no source sets a value. It
+// deliberately has no database password or JWT secret.
+func Default() *Config {
+	return &Config{
+		App: AppConfig{Env: EnvDevelopment},
+		Server: ServerConfig{
+			Port:              8080,
+			ReadTimeout:       Duration{15 * time.Second},
+			ReadHeaderTimeout: Duration{5 * time.Second},
+			WriteTimeout:      Duration{30 * time.Second},
+			IdleTimeout:       Duration{60 * time.Second},
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   Duration{20 * time.Second},
+		},
+		Database: DatabaseConfig{
+			Driver:          "postgres",
+			Path:            "football_go.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "football_go",
+			SSLMode:         "disable",
+			TimeZone:        "Asia/Jakarta",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration{30 * time.Minute},
+			ConnMaxIdleTime: Duration{5 * time.Minute},
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "X-Request-ID"},
+			MaxAge:         Duration{12 * time.Hour},
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 10,
+			Burst:             20,
+		},
+		Log:     LogConfig{Level: "info", SlowQueryThreshold: Duration{200 * time.Millisecond}},
+		Metrics: MetricsConfig{Enabled: true},
+		Tracing: TracingConfig{Exporter: "none", ServiceName: "football-go", SampleRatio: 1},
+		Trash:   TrashConfig{RetentionDays: 30},
+		Mail:    MailConfig{Driver: "log", Port: 587},
+		Import:  ImportConfig{MaxBytes: 10 << 20},
+	}
+}
+
+// Load builds the configuration from args (without the program name) and
+// the environment, including a .env file when present. The config file is
+// taken from the -config flag or CONFIG_FILE. The arguments left after the
+// flags are returned for the caller to dispatch commands on. Load does not
+// validate the result; call Validate for that.
+func Load(args []string) (*Config, []string, error) {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
 
-	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", "postgres"),
-		DBName:     getEnv("DB_NAME", "football_go"),
-		JWTSecret:  getEnv("JWT_SECRET", "secret"),
-		AppPort:    getEnv("APP_PORT", "8080"),
+	cfg := Default()
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("football-go", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+
+	type flagValue struct {
+		field *field
+		value string
+	}
+	var flagValues []flagValue
+	for i := range fields {
+		f := &fields[i]
+		fs.Func(f.key, fmt.Sprintf("overrides %s", f.env), func(value string) error {
+			flagValues = append(flagValues, flagValue{field: f, value: value})
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, nil, err
+		}
 	}
-}
 
-func getEnv(key, fallback string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+	for _, f := range fields {
+		if value, ok := os.LookupEnv(f.env); ok {
+			if err := f.set(value); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
 	}
-	return fallback
+
+	for _, fv := range flagValues {
+		if err := fv.field.set(fv.value); err != nil {
+			return nil, nil, fmt.Errorf("-%s: %w", fv.field.key, err)
+		}
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+func (c *Config) IsDevelopment() bool {
+	return c.App.Env == EnvDevelopment
 }
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+database:
+  host: file-host
+  user: file-user
+  conn_max_lifetime: 1h
+log:
+  level: warn
+`)
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("LOG_LEVEL", "error")
+
+	cfg, args, err := Load([]string{"-config", file, "-log.level", "debug", "migrate", "up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.Port != 9000 || cfg.Database.User != "file-user" {
+		t.Errorf("file values not applied: port %d, user %q", cfg.Server.Port, cfg.Database.User)
+	}
+	if cfg.Database.Host != "env-host" {
+		t.Errorf("database.host = %q, want the env value", cfg.Database.Host)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("log.level = %q, want the flag value", cfg.Log.Level)
+	}
+	if cfg.Database.ConnMaxLifetime.Duration != time.Hour {
+		t.Errorf("database.conn_max_lifetime = %v, want 1h", cfg.Database.ConnMaxLifetime)
+	}
+	if cfg.Database.Name != "football_go" {
+		t.Errorf("database.name = %q, want the default", cfg.Database.Name)
+	}
+	if strings.Join(args, " ") != "migrate up" {
+		t.Errorf("args = %v, want [migrate up]", args)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	file := writeFile(t, "config.toml", `
+[cors]
+allowed_origins = ["https://example.com"]
+
+[rate_limit]
+enabled = true
+requests_per_second = 2.5
+`)
+	t.Setenv("CONFIG_FILE", file)
+	t.Setenv("CORS_ALLOWED_METHODS", "GET, POST")
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 1 || cfg.CORS.AllowedOrigins[0] != "https://example.com" {
+		t.Errorf("cors.allowed_origins = %v", cfg.CORS.AllowedOrigins)
+	}
+	if strings.Join(cfg.CORS.AllowedMethods, ",") != "GET,POST" {
+		t.Errorf("cors.allowed_methods = %v", cfg.CORS.AllowedMethods)
+	}
+	if !cfg.RateLimit.Enabled || cfg.RateLimit.RequestsPerSecond != 2.5 {
+		t.Errorf("rate_limit = %+v", cfg.RateLimit)
+	}
+}
+
+func TestLoadRejectsBadInput(t *testing.T) {
+	tests := map[string][]string{
+		"unknown file key": {"-config", writeFile(t, "config.yaml", "database:\n  hots: x\n")},
+		"unknown format":   {"-config", writeFile(t, "config.json", "{}")},
+		"bad flag value":   {"-server.port", "http"},
+		"unknown flag":     {"-server.bogus", "1"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := Load(args); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func validConfig() *Config {
+	cfg := Default()
+	cfg.Auth.JWTSecret = strings.Repeat("x", MinJWTSecretLength)
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("default config with a secret is invalid: %v", err)
+	}
+
+	tests := map[string]struct {
+		modify func(*Config)
+		want   string
+	}{
+		"missing secret":      {func(c *Config) { c.Auth.JWTSecret = "" }, "auth.jwt_secret: is required"},
+		"short secret":        {func(c *Config) { c.App.Env = EnvProduction; c.Auth.JWTSecret = "secret" }, "auth.jwt_secret: must be at least"},
+		"missing db password": {func(c *Config) { c.App.Env = EnvProduction }, "database.password: is required"},
+		"ssl mode":            {func(c *Config) { c.Database.SSLMode = "on" }, "database.ssl_mode"},
+		"time zone":           {func(c *Config) { c.Database.TimeZone = "Mars/Olympus" }, "database.time_zone"},
+		"pool":                {func(c *Config) { c.Database.MaxIdleConns = 50 }, "database.max_idle_conns"},
+		"tls files":           {func(c *Config) { c.Server.TLS.Enabled = true }, "server.tls.cert_file"},
+		"wildcard origin":     {func(c *Config) { c.CORS.AllowedOrigins = []string{"*"}; c.CORS.AllowCredentials = true }, "cors.allowed_origins"},
+		"origin with path":    {func(c *Config) { c.CORS.AllowedOrigins = []string{"https://example.com/app"} }, "cors.allowed_origins"},
+		"rate limit":          {func(c *Config) { c.RateLimit.Enabled = true; c.RateLimit.Burst = 0 }, "rate_limit.burst"},
+		"log level":           {func(c *Config) { c.Log.Level = "verbose" }, "log.level"},
+		"open metrics":        {func(c *Config) { c.App.Env = EnvProduction }, "metrics.token: is required outside development"},
+		"templates dir":       {func(c *Config) { c.Report.TemplatesDir = "/no/such/dir" }, "report.templates_dir"},
+		"import size":         {func(c *Config) { c.Import.MaxBytes = 0 }, "import.max_bytes"},
+		"log mailer":          {func(c *Config) { c.App.Env = "staging"; c.Database.Password = "x" }, "mail.driver: log only writes mail"},
+		"smtp host":           {func(c *Config) { c.Mail.Driver = "smtp"; c.Mail.From = "no-reply@example.com" }, "mail.host"},
+		"smtp from":           {func(c *Config) { c.Mail.Driver = "smtp"; c.Mail.Host = "smtp.example.com" }, "mail.from"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Password = "hunter2"
+
+	var buf bytes.Buffer
+	if err := cfg.Print(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "hunter2") || strings.Contains(out, cfg.Auth.JWTSecret) {
+		t.Errorf("secrets leaked:\n%s", out)
+	}
+	if !strings.Contains(out, "password: '******'") {
+		t.Errorf("password not redacted:\n%s", out)
+	}
+	if cfg.Database.Password != "hunter2" {
+		t.Error("Print modified the config")
+	}
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MinJWTSecretLength is the shortest HMAC secret accepted outside
+// development.
+const MinJWTSecretLength = 32
+
+const redacted = "******"
+
+var (
+	envs      = []string{EnvDevelopment, "staging", EnvProduction}
+	drivers   = []string{"postgres", "sqlite"}
+	sslModes  = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels = []string{"debug", "info", "warn", "error"}
+	exporters = []string{"none", "otlp", "stdout"}
+	mailers   = []string{"log", "smtp"}
+)
+
+// Validate checks the configuration and reports every problem at once, one
+// "key: problem" line each.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(slices.Contains(envs, c.App.Env), "app.env", "must be one of %v, got %q", envs, c.App.Env)
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	for _, timeout := range []struct {
+		key   string
+		value Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+	} {
+		check(timeout.value.Duration > 0, timeout.key, "must be positive, got %s", timeout.value)
+	}
+	check(c.Server.MaxHeaderBytes >= 4096, "server.max_header_bytes", "must be at least 4096, got %d", c.Server.MaxHeaderBytes)
+	if c.Server.TLS.Enabled {
+		checkFile := func(key, path string) {
+			if path == "" {
+				check(false, key, "is required when server.tls.enabled is set")
+				return
+			}
+			_, err := os.Stat(path)
+			check(err == nil, key, "%v", err)
+		}
+		checkFile("server.tls.cert_file", c.Server.TLS.CertFile)
+		checkFile("server.tls.key_file", c.Server.TLS.KeyFile)
+	}
+
+	db := c.Database
+	check(slices.Contains(drivers, db.Driver), "database.driver", "must be one of %v, got %q", drivers, db.Driver)
+	switch db.Driver {
+	case "postgres":
+		check(db.Host != "", "database.host", "is required")
+		check(db.Port > 0 && db.Port < 65536, "database.port", "must be between 1 and 65535, got %d", db.Port)
+		check(db.User != "", "database.user", "is required")
+		check(db.Name != "", "database.name", "is required")
+		check(slices.Contains(sslModes, db.SSLMode), "database.ssl_mode", "must be one of %v, got %q", sslModes, db.SSLMode)
+		if !c.IsDevelopment() {
+			check(db.Password != "", "database.password", "is required outside development")
+		}
+	case "sqlite":
+		check(db.Path != "", "database.path", "is required")
+	}
+	_, err := time.LoadLocation(db.TimeZone)
+	check(db.TimeZone != "" && err == nil, "database.time_zone", "unknown time zone %q", db.TimeZone)
+	check(db.MaxOpenConns >= 0, "database.max_open_conns", "must not be negative")
+	check(db.MaxIdleConns >= 0, "database.max_idle_conns", "must not be negative")
+	check(db.MaxOpenConns == 0 || db.MaxIdleConns <= db.MaxOpenConns, "database.max_idle_conns", "must not exceed database.max_open_conns (%d)", db.MaxOpenConns)
+	check(db.ConnMaxLifetime.Duration >= 0, "database.conn_max_lifetime", "must not be negative")
+	check(db.ConnMaxIdleTime.Duration >= 0, "database.conn_max_idle_time", "must not be negative")
+
+	if c.Auth.JWTKeys == "" {
+		check(c.Auth.JWTSecret != "", "auth.jwt_secret", "is required unless auth.jwt_keys is set")
+		if c.Auth.JWTSecret != "" && !c.IsDevelopment() {
+			check(len(c.Auth.JWTSecret) >= MinJWTSecretLength, "auth.jwt_secret", "must be at least %d bytes outside development", MinJWTSecretLength)
+		}
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			check(!c.CORS.AllowCredentials, "cors.allowed_origins", `"*" cannot be combined with cors.allow_credentials`)
+			continue
+		}
+		u, err := url.Parse(origin)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "",
+			"cors.allowed_origins", "%q is not an origin such as https://example.com", origin)
+	}
+	check(c.CORS.MaxAge.Duration >= 0, "cors.max_age", "must not be negative")
+
+	if c.RateLimit.Enabled {
+		check(c.RateLimit.RequestsPerSecond > 0, "rate_limit.requests_per_second", "must be positive")
+		check(c.RateLimit.Burst > 0, "rate_limit.burst", "must be positive")
+	}
+
+	if c.Metrics.Enabled && !c.IsDevelopment() {
+		check(c.Metrics.Token != "", "metrics.token", "is required outside development while metrics.enabled is true")
+	}
+
+	check(slices.Contains(logLevels, c.Log.Level), "log.level", "must be one of %v, got %q", logLevels, c.Log.Level)
+	check(c.Log.SlowQueryThreshold.Duration >= 0, "log.slow_query_threshold", "must not be negative")
+
+	check(slices.Contains(exporters, c.Tracing.Exporter), "tracing.exporter", "must be one of %v, got %q", exporters, c.Tracing.Exporter)
+	if c.Tracing.Endpoint != "" {
+		u, err := url.Parse(c.Tracing.Endpoint)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"tracing.endpoint", "%q is not a URL such as http://localhost:4318", c.Tracing.Endpoint)
+	}
+	check(c.Tracing.ServiceName != "", "tracing.service_name", "is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	check(c.Trash.RetentionDays >= 0, "trash.retention_days", "must not be negative")
+
+	if c.Report.TemplatesDir != "" {
+		info, err := os.Stat(c.Report.TemplatesDir)
+		check(err == nil && info.IsDir(), "report.templates_dir", "%q is not a directory", c.Report.TemplatesDir)
+	}
+
+	check(c.Import.MaxBytes > 0, "import.max_bytes", "must be positive, got %d", c.Import.MaxBytes)
+
+	check(slices.Contains(mailers, c.Mail.Driver), "mail.driver", "must be one of %v, got %q", mailers, c.Mail.Driver)
+	switch c.Mail.Driver {
+	case "log":
+		check(c.IsDevelopment(), "mail.driver", "log only writes mail to the log and is not allowed outside development")
+	case "smtp":
+		check(c.Mail.Host != "", "mail.host", "is required for the smtp driver")
+		check(c.Mail.Port > 0 && c.Mail.Port < 65536, "mail.port", "must be between 1 and 65535, got %d", c.Mail.Port)
+		_, err := mail.ParseAddress(c.Mail.From)
+		check(err == nil, "mail.from", "%q is not an address such as Football Go <no-reply@example.com>", c.Mail.From)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print writes the configuration as YAML with secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	out := *c
+	for _, f := range out.fields() {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&out); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/pranotoism/football-go/config"
+)
+
+// runConfig implements the config subcommand, which prints the effective
+// configuration with secrets redacted followed by any validation errors.
+func runConfig(cfg *config.Config) {
+	if err := cfg.Print(os.Stdout); err != nil {
+		log.Fatal("Failed to print config:", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "\nInvalid config:\n%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,284 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/util"
+	"gorm.io/gorm"
+)
+
+const auditBeforeKey = "audit:before"
+
+var (
+	auditSkippedTables  = map[string]bool{"audit_logs": true, "schema_migrations": true}
+	auditRedactedFields = map[string]bool{"password": true, "email_verification_token": true}
+	auditIgnoredChanges = map[string]bool{"updated_at": true}
+
+	// Personal data of users is kept out of the trail on top of the fields
+	// redacted everywhere
+	auditRedactedTableFields = map[string]map[string]bool{
+		"users": {"email": true, "pending_email": true, "token_version": true},
+	}
+)
+
+// AuditPlugin records an audit_logs entry for every row created, updated or
+// deleted through GORM. Because it is registered on the callback chain, any
+// repository using the shared *gorm.DB is covered without extra wiring. The
+// acting user and request ID are read from the statement context.
+type AuditPlugin struct{}
+
+func NewAuditPlugin() *AuditPlugin {
+	return &AuditPlugin{}
+}
+
+func (p *AuditPlugin) Name() string {
+	return "audit"
+}
+
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Register("audit:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("audit:before_update", p.captureBefore); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("audit:before_delete", p.captureBefore); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Register("audit:after_delete", p.afterDelete)
+}
+
+func (p *AuditPlugin) afterCreate(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	ids := primaryKeys(db)
+	if len(ids) == 0 {
+		return
+	}
+
+	after := p.loadRows(db, ids)
+	entries := make([]model.AuditLog, 0, len(after))
+	for id, row := range after {
+		entries = append(entries, p.newEntry(db, "create", id, nil, row))
+	}
+	p.write(db, entries)
+}
+
+func (p *AuditPlugin) captureBefore(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	q := p.newQuery(db)
+	if ids := primaryKeys(db); len(ids) > 0 {
+		q = q.Where(db.Statement.Schema.PrioritizedPrimaryField.DBName+" IN ?", ids)
+	} else if where, ok := db.Statement.Clauses["WHERE"]; ok {
+		q = q.Clauses(where.Expression)
+	} else {
+		return
+	}
+
+	// Rows that are already soft-deleted are not touched by a scoped
+	// update or delete, so they must not show up in the audit trail.
+	if field := db.Statement.Schema.LookUpField("DeletedAt"); field != nil && !db.Statement.Unscoped {
+		q = q.Where(field.DBName + " IS NULL")
+	}
+
+	db.InstanceSet(auditBeforeKey, p.query(db, q))
+}
+
+func (p *AuditPlugin) afterUpdate(db *gorm.DB) {
+	before := p.takeBefore(db)
+	if len(before) == 0 || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+
+	after := p.loadRows(db, ids)
+	entries := make([]model.AuditLog, 0, len(before))
+	for id, old := range before {
+		row, ok := after[id]
+		if !ok {
+			continue
+		}
+		entries = append(entries, p.newEntry(db, "update", id, old, row))
+	}
+	p.write(db, entries)
+}
+
+func (p *AuditPlugin) afterDelete(db *gorm.DB) {
+	before := p.takeBefore(db)
+	if len(before) == 0 || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	entries := make([]model.AuditLog, 0, len(before))
+	for id, old := range before {
+		entries = append(entries, p.newEntry(db, "delete", id, old, nil))
+	}
+	p.write(db, entries)
+}
+
+func (p *AuditPlugin) shouldAudit(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil && !stmt.DryRun && stmt.Schema != nil &&
+		stmt.Schema.PrioritizedPrimaryField != nil && !auditSkippedTables[stmt.Table]
+}
+
+func (p *AuditPlugin) takeBefore(db *gorm.DB) map[uint]map[string]interface{} {
+	if db.Error != nil {
+		return nil
+	}
+	value, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return nil
+	}
+	before, _ := value.(map[uint]map[string]interface{})
+	return before
+}
+
+func (p *AuditPlugin) newQuery(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table)
+}
+
+func (p *AuditPlugin) loadRows(db *gorm.DB, ids []uint) map[uint]map[string]interface{} {
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	return p.query(db, p.newQuery(db).Where(pk+" IN ?", ids))
+}
+
+func (p *AuditPlugin) query(db *gorm.DB, q *gorm.DB) map[uint]map[string]interface{} {
+	var rows []map[string]interface{}
+	if err := q.Find(&rows).Error; err != nil {
+		db.Logger.Error(db.Statement.Context, "audit: failed to load rows: %v", err)
+		return nil
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	result := make(map[uint]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		id, ok := toUint(row[pk])
+		if !ok {
+			continue
+		}
+		for field := range row {
+			if auditRedactedFields[field] || auditRedactedTableFields[db.Statement.Table][field] {
+				row[field] = "[redacted]"
+			}
+		}
+		result[id] = row
+	}
+	return result
+}
+
+func (p *AuditPlugin) newEntry(db *gorm.DB, action string, id uint, before, after map[string]interface{}) model.AuditLog {
+	ctx := db.Statement.Context
+	entry := model.AuditLog{
+		Entity:    db.Statement.Table,
+		EntityID:  id,
+		Action:    action,
+		RequestID: util.RequestIDFromContext(ctx),
+		Before:    marshalRow(before),
+		After:     marshalRow(after),
+	}
+	if actorID, ok := util.ActorIDFromContext(ctx); ok {
+		entry.ActorID = &actorID
+	}
+	if before != nil && after != nil {
+		entry.Changes = marshalRow(diffRows(before, after))
+	}
+	return entry
+}
+
+func (p *AuditPlugin) write(db *gorm.DB, entries []model.AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+func primaryKeys(db *gorm.DB) []uint {
+	stmt := db.Statement
+	field := stmt.Schema.PrioritizedPrimaryField
+	var ids []uint
+
+	collect := func(rv reflect.Value) {
+		if value, zero := field.ValueOf(stmt.Context, rv); !zero {
+			if id, ok := toUint(value); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collect(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		collect(rv)
+	}
+	return ids
+}
+
+func diffRows(before, after map[string]interface{}) map[string]interface{} {
+	changes := make(map[string]interface{})
+	for field, newValue := range after {
+		if auditIgnoredChanges[field] {
+			continue
+		}
+		oldValue := before[field]
+		if marshalValue(oldValue) != marshalValue(newValue) {
+			changes[field] = map[string]interface{}{"before": oldValue, "after": newValue}
+		}
+	}
+	return changes
+}
+
+func marshalRow(row map[string]interface{}) string {
+	if row == nil {
+		return ""
+	}
+	return marshalValue(row)
+}
+
+func marshalValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+func toUint(value interface{}) (uint, bool) {
+	switch v := value.(type) {
+	case uint:
+		return v, true
+	case uint32:
+		return uint(v), true
+	case uint64:
+		return uint(v), true
+	case int:
+		return uint(v), v >= 0
+	case int32:
+		return uint(v), v >= 0
+	case int64:
+		return uint(v), v >= 0
+	}
+	return 0, false
+}
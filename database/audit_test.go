@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/util"
+)
+
+func TestAuditPluginRecordsMutations(t *testing.T) {
+	ctx := util.WithActorID(context.Background(), 7)
+	db := databasetest.New(t).WithContext(ctx)
+
+	team := model.Team{Name: "PSIS", FoundedYear: 1932}
+	if err := db.Create(&team).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&team).Update("hq_city", "Semarang").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&team).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []model.AuditLog
+	if err := db.Where("entity = ? AND entity_id = ?", "teams", team.ID).Order("id").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"create", "update", "delete"}
+	if len(logs) != len(want) {
+		t.Fatalf("got %d audit entries, want %d", len(logs), len(want))
+	}
+	for i, log := range logs {
+		if log.Action != want[i] {
+			t.Errorf("entry %d action = %q, want %q", i, log.Action, want[i])
+		}
+		if log.ActorID == nil || *log.ActorID != 7 {
+			t.Errorf("entry %d actor = %v, want 7", i, log.ActorID)
+		}
+	}
+}
+
+func TestAuditPluginRedactsUsers(t *testing.T) {
+	db := databasetest.New(t)
+
+	user := model.User{Name: "Rina", Email: "rina@example.com", Password: "hash", Role: model.RoleReporter}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&user).Updates(map[string]interface{}{"pending_email": "new@example.com", "token_version": 2, "name": "Rina S"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []model.AuditLog
+	if err := db.Where("entity = ? AND entity_id = ?", "users", user.ID).Order("id").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(logs))
+	}
+	for _, log := range logs {
+		for _, secret := range []string{"rina@example.com", "new@example.com", "hash"} {
+			if strings.Contains(log.Before+log.After+log.Changes, secret) {
+				t.Errorf("%s entry exposes %q", log.Action, secret)
+			}
+		}
+	}
+	if !strings.Contains(logs[1].Changes, "Rina S") || strings.Contains(logs[1].Changes, "token_version") {
+		t.Errorf("changes = %s, want the name change only", logs[1].Changes)
+	}
+}
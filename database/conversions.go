@@ -0,0 +1,89 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// conversion moves data between columns where SQL cannot, such as reading
+// local dates in a time zone that SQLite knows nothing about. Each step runs
+// after the script of its version and direction, in the same transaction.
+type conversion struct {
+	up, down func(tx *gorm.DB, loc *time.Location) error
+}
+
+var conversions = map[int64]conversion{
+	8: {up: convertKickoffs},
+	9: {down: restoreMatchDates},
+}
+
+// convertKickoffs fills kickoff_at and time_zone from the match_date and
+// match_time strings, which were local to loc. A date or time that does not
+// parse stops the migration rather than move the match to a kickoff nobody
+// chose.
+func convertKickoffs(tx *gorm.DB, loc *time.Location) error {
+	var rows []struct {
+		ID        uint
+		MatchDate string
+		MatchTime string
+	}
+	if err := tx.Raw("SELECT id, match_date, match_time FROM matches").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if _, err := time.ParseInLocation(time.DateOnly, row.MatchDate, loc); err != nil {
+			return fmt.Errorf("match %d has date %q, which is not YYYY-MM-DD; correct it and migrate again", row.ID, row.MatchDate)
+		}
+		var kickoff time.Time
+		for _, layout := range []string{"15:04", time.TimeOnly} {
+			if t, err := time.ParseInLocation(time.DateOnly+" "+layout, row.MatchDate+" "+row.MatchTime, loc); err == nil {
+				kickoff = t
+				break
+			}
+		}
+		if kickoff.IsZero() {
+			return fmt.Errorf("match %d has time %q, which is not HH:MM or HH:MM:SS; correct it and migrate again", row.ID, row.MatchTime)
+		}
+
+		err := tx.Exec("UPDATE matches SET kickoff_at = ?, time_zone = ? WHERE id = ?", kickoff.UTC(), loc.String(), row.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreMatchDates fills match_date and match_time back in from the
+// kickoff, in the time zone of each match. Times are written as HH:MM, or
+// HH:MM:SS when they have seconds, so that migrating up again restores the
+// same kickoff.
+func restoreMatchDates(tx *gorm.DB, _ *time.Location) error {
+	var rows []struct {
+		ID        uint
+		KickoffAt time.Time
+		TimeZone  string
+	}
+	if err := tx.Raw("SELECT id, kickoff_at, time_zone FROM matches").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		loc, err := time.LoadLocation(row.TimeZone)
+		if err != nil {
+			return fmt.Errorf("match %d: %w", row.ID, err)
+		}
+		local := row.KickoffAt.In(loc)
+		clock := local.Format("15:04")
+		if local.Second() != 0 {
+			clock = local.Format(time.TimeOnly)
+		}
+		err = tx.Exec("UPDATE matches SET match_date = ?, match_time = ? WHERE id = ?", local.Format(time.DateOnly), clock, row.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
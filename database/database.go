@@ -1,35 +1,109 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/pranotoism/football-go/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func Connect(cfg *config.Config) *gorm.DB {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
-		cfg.DBHost, cfg.DBUser, cfg.DBName, cfg.DBPort,
-	)
-	if cfg.DBPassword != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.DBPassword)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+func Connect(cfg *config.Config) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Database.Driver {
+	case DriverPostgres:
+		dialector = postgres.Open(PostgresDSN(cfg.Database))
+	case DriverSQLite:
+		dialector = SQLite(cfg.Database.Path)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, expected %s or %s", cfg.Database.Driver, DriverPostgres, DriverSQLite)
+	}
+
+	db, err := Open(dialector, &gorm.Config{Logger: NewLogger(cfg.Log.SlowQueryThreshold.Duration)})
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return nil, fmt.Errorf("configure connection pool: %w", err)
 	}
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime.Duration)
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime.Duration)
+
+	slog.Info("database connected", "driver", cfg.Database.Driver)
+	return db, nil
+}
+
+// PostgresDSN builds a connection URL for cfg, escaping every part.
+func PostgresDSN(cfg config.DatabaseConfig) string {
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:   "/" + cfg.Name,
+		RawQuery: url.Values{
+			"sslmode":  {cfg.SSLMode},
+			"TimeZone": {cfg.TimeZone},
+		}.Encode(),
+	}
+	if cfg.Password != "" {
+		u.User = url.UserPassword(cfg.User, cfg.Password)
+	} else {
+		u.User = url.User(cfg.User)
+	}
+	return u.String()
+}
 
-	log.Println("Database connected successfully")
-	return db
+// SQLite returns a dialector for the database file at path with foreign key
+// enforcement switched on.
+func SQLite(path string) gorm.Dialector {
+	return sqlite.Open(path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
 }
 
-func Migrate(db *gorm.DB, models ...interface{}) {
-	if err := db.AutoMigrate(models...); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+// Open connects through dialector and registers the plugins every
+// connection needs.
+func Open(dialector gorm.Dialector, opts ...gorm.Option) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(NewAuditPlugin()); err != nil {
+		return nil, fmt.Errorf("register audit plugin: %w", err)
+	}
+	if err := db.Use(NewMetricsPlugin()); err != nil {
+		return nil, fmt.Errorf("register metrics plugin: %w", err)
+	}
+	if err := db.Use(NewTracingPlugin()); err != nil {
+		return nil, fmt.Errorf("register tracing plugin: %w", err)
+	}
+	return db, nil
+}
+
+// Migrate applies every pending migration and returns the migrator for
+// later status checks.
+func Migrate(db *gorm.DB, loc *time.Location) (*Migrator, error) {
+	migrator, err := NewMigrator(db, loc)
+	if err != nil {
+		return nil, fmt.Errorf("load migrations: %w", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		return nil, fmt.Errorf("migrate database: %w", err)
 	}
-	log.Println("Database migrated successfully")
+	slog.Info("database migrated", "version", migrator.Latest())
+	return migrator, nil
 }
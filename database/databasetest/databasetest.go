@@ -0,0 +1,104 @@
+// Package databasetest provides throwaway SQLite databases for tests, and
+// PostgreSQL ones when TEST_POSTGRES_DSN points at a server.
+package databasetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// PostgresEnv names the environment variable holding the connection string
+// of the PostgreSQL server used by Postgres.
+const PostgresEnv = "TEST_POSTGRES_DSN"
+
+// New returns a database in the test's temporary directory with every
+// migration applied. It is closed when the test finishes.
+func New(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Open(database.SQLite(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	closeOnCleanup(t, db)
+	return migrate(t, db)
+}
+
+// Postgres returns a database in a schema of its own on the server named by
+// TEST_POSTGRES_DSN, with every migration applied, and skips the test when
+// the variable is unset. The schema is dropped when the test finishes.
+func Postgres(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(PostgresEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", PostgresEnv)
+	}
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect to %s: %v", PostgresEnv, err)
+	}
+	closeOnCleanup(t, admin)
+
+	suffix := make([]byte, 6)
+	rand.Read(suffix)
+	schema := "test_" + hex.EncodeToString(suffix)
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	// Registered after closeOnCleanup, so it runs while admin is still open
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	// Extensions such as pg_trgm stay reachable through public
+	db, err := database.Open(postgres.Open(withSearchPath(dsn, schema+",public")))
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	closeOnCleanup(t, db)
+	return migrate(t, db)
+}
+
+func withSearchPath(dsn, path string) string {
+	if u, err := url.Parse(dsn); err == nil && strings.Contains(dsn, "://") {
+		query := u.Query()
+		query.Set("search_path", path)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+	return dsn + " search_path=" + path
+}
+
+func closeOnCleanup(t testing.TB, db *gorm.DB) {
+	t.Helper()
+	db.Logger = logger.Discard
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+}
+
+func migrate(t testing.TB, db *gorm.DB) *gorm.DB {
+	t.Helper()
+	migrator, err := database.NewMigrator(db, time.UTC)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Logger sends GORM's logs to slog with the statement context, so SQL logs
+// carry the request ID. Statements are logged at debug, slow ones at warn
+// and failed ones at error; which of them appear is decided by the slog
+// level. Statements are logged with placeholders in place of their values,
+// which include password hashes and verification tokens.
+type Logger struct {
+	slowThreshold time.Duration
+}
+
+func NewLogger(slowThreshold time.Duration) *Logger {
+	return &Logger{slowThreshold: slowThreshold}
+}
+
+// LogMode is a no-op: filtering is left to the slog handler.
+func (l *Logger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, args ...any) {
+	slog.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, args ...any) {
+	slog.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, args ...any) {
+	slog.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// ParamsFilter drops the values bound to sql, so that GORM hands Trace the
+// statement alone.
+func (l *Logger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	return sql, nil
+}
+
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	level := slog.LevelDebug
+	msg := "database query"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "database query failed"
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		level, msg = slog.LevelWarn, "slow database query"
+	}
+
+	if !slog.Default().Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if err != nil && level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	slog.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/database"
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/logging"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/util"
+)
+
+func TestLoggerWritesSQLWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, "debug"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	db := databasetest.New(t)
+	db.Logger = database.NewLogger(0)
+
+	ctx := util.WithRequestID(context.Background(), "req-7")
+	db.WithContext(ctx).Find(&[]model.Team{})
+	db.WithContext(ctx).Exec("SELECT * FROM missing_table")
+
+	var query, failure map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON log line %q", line)
+		}
+		switch record["msg"] {
+		case "database query":
+			query = record
+		case "database query failed":
+			failure = record
+		}
+	}
+
+	if query == nil || query["request_id"] != "req-7" || !strings.Contains(query["sql"].(string), "teams") {
+		t.Errorf("query record = %v", query)
+	}
+	if failure == nil || failure["level"] != "ERROR" || failure["request_id"] != "req-7" {
+		t.Errorf("failure record = %v", failure)
+	}
+}
+
+func TestLoggerLeavesOutValues(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, "debug"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	db := databasetest.New(t)
+	db.Logger = database.NewLogger(0)
+
+	user := model.User{Name: "Rina", Email: "rina@example.com", Password: "$2a$10$secrethash", Role: model.RoleReporter}
+	db.Create(&user)
+	db.Where("email = ?", "rina@example.com").First(&model.User{})
+	// Fails on the unique email, and is logged at error
+	db.Create(&model.User{Name: "Rina", Email: "rina@example.com", Password: "$2a$10$otherhash", Role: model.RoleReporter})
+
+	out := buf.String()
+	if !strings.Contains(out, `"level":"ERROR"`) || !strings.Contains(out, "INSERT INTO") {
+		t.Fatalf("expected debug and error records of the statements:\n%s", out)
+	}
+	for _, secret := range []string{"rina@example.com", "secrethash", "otherhash"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("SQL log contains %q:\n%s", secret, out)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pranotoism/football-go/metrics"
+	"gorm.io/gorm"
+)
+
+const metricsStartKey = "metrics:start"
+
+// MetricsPlugin records the duration of every GORM operation and counts
+// the ones that fail.
+type MetricsPlugin struct{}
+
+func NewMetricsPlugin() *MetricsPlugin {
+	return &MetricsPlugin{}
+}
+
+func (p *MetricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	type processor interface {
+		Register(name string, fn func(*gorm.DB)) error
+	}
+	register := func(operation string, before, after processor) error {
+		if err := before.Register("metrics:before_"+operation, p.start); err != nil {
+			return err
+		}
+		return after.Register("metrics:after_"+operation, p.observe(operation))
+	}
+
+	if err := register("create", cb.Create().Before("*"), cb.Create().After("*")); err != nil {
+		return err
+	}
+	if err := register("query", cb.Query().Before("*"), cb.Query().After("*")); err != nil {
+		return err
+	}
+	if err := register("update", cb.Update().Before("*"), cb.Update().After("*")); err != nil {
+		return err
+	}
+	if err := register("delete", cb.Delete().Before("*"), cb.Delete().After("*")); err != nil {
+		return err
+	}
+	if err := register("row", cb.Row().Before("*"), cb.Row().After("*")); err != nil {
+		return err
+	}
+	return register("raw", cb.Raw().Before("*"), cb.Raw().After("*"))
+}
+
+func (p *MetricsPlugin) start(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *MetricsPlugin) observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		metrics.DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			metrics.DBQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey identifies the advisory lock held while migrations run,
+// so that several instances starting at once do not migrate concurrently.
+const migrationLockKey = 4_172_093_511
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies the SQL scripts embedded under migrations/<dialect> and
+// records the applied versions in the schema_migrations table. Both dialects
+// share the same version numbers. Local dates and times that migrations
+// convert are read in loc.
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+	loc        *time.Location
+}
+
+func NewMigrator(db *gorm.DB, loc *time.Location) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if dialect != DriverPostgres && dialect != DriverSQLite {
+		return nil, fmt.Errorf("no migrations for database dialect %q", dialect)
+	}
+
+	migrations, err := loadMigrations(migrationFiles, path.Join("migrations", dialect))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations, loc: loc}, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		m := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the version of the newest embedded migration.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				return m.rollback(conn, m.migrations[i])
+			}
+		}
+		return nil
+	})
+}
+
+// To migrates up or down until version is the newest applied migration.
+// Version 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > version {
+				if err := m.rollback(conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+				if err := m.apply(conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists every embedded migration and when it was applied, if at all.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		result = make([]MigrationStatus, len(m.migrations))
+		for i, migration := range m.migrations {
+			result[i] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if at, ok := applied[migration.Version]; ok {
+				result[i].AppliedAt = &at
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Version returns the newest applied migration, or 0 when none has been
+// applied. Unlike Status it takes no lock, so it is cheap enough for health
+// checks.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.db.WithContext(ctx).Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock runs fn on a single connection reserved for the migration. On
+// Postgres that connection holds an advisory lock for the whole call. SQLite
+// already serializes writers; there foreign key enforcement is switched off
+// instead, because table rebuilds would otherwise trip it, and apply checks
+// the references itself before committing.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		createTable := `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		)`
+
+		switch m.dialect {
+		case DriverPostgres:
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+				return fmt.Errorf("acquire migration lock: %w", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+		case DriverSQLite:
+			if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+				return err
+			}
+			defer conn.Exec("PRAGMA foreign_keys = ON")
+
+			createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+				version    INTEGER PRIMARY KEY,
+				name       VARCHAR(255) NOT NULL,
+				applied_at DATETIME NOT NULL
+			)`
+		}
+
+		if err := conn.Exec(createTable).Error; err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]time.Time, error) {
+	var rows []schemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		if convert := conversions[migration.Version].up; convert != nil {
+			if err := convert(tx, m.loc); err != nil {
+				return err
+			}
+		}
+		if err := m.checkForeignKeys(tx); err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) rollback(conn *gorm.DB, migration Migration) error {
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		if convert := conversions[migration.Version].down; convert != nil {
+			if err := convert(tx, m.loc); err != nil {
+				return err
+			}
+		}
+		if err := m.checkForeignKeys(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// checkForeignKeys reports references broken by a migration that ran with
+// SQLite foreign key enforcement switched off.
+func (m *Migrator) checkForeignKeys(tx *gorm.DB) error {
+	if m.dialect != DriverSQLite {
+		return nil
+	}
+
+	var violations []struct {
+		Table  string
+		Parent string
+	}
+	if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d rows in %s reference missing %s rows", len(violations), violations[0].Table, violations[0].Parent)
+	}
+	return nil
+}
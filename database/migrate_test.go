@@ -0,0 +1,190 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/database"
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+)
+
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+
+	migrator, err := database.NewMigrator(db, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d_%s not applied", s.Version, s.Name)
+		}
+	}
+
+	if v, err := migrator.Version(ctx); err != nil || v != migrator.Latest() {
+		t.Errorf("Version() = %d, %v; want %d", v, err, migrator.Latest())
+	}
+
+	if err := migrator.Down(ctx); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if v, _ := migrator.Version(ctx); v != migrator.Latest()-1 {
+		t.Errorf("Version() after down = %d, want %d", v, migrator.Latest()-1)
+	}
+	statuses, _ = migrator.Status(ctx)
+	if last := statuses[len(statuses)-1]; last.AppliedAt != nil {
+		t.Errorf("migration %d still applied after down", last.Version)
+	}
+
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("to 0: %v", err)
+	}
+	if db.Migrator().HasTable("teams") {
+		t.Error("teams table still exists after rolling back everything")
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := migrator.To(ctx, 99); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+}
+
+func TestMigratorKeepsRowsAndConstraints(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+
+	migrator, err := database.NewMigrator(db, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.To(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	team := model.Team{Name: "Persija", FoundedYear: 1928}
+	if err := db.Create(&team).Error; err != nil {
+		t.Fatal(err)
+	}
+	player := model.Player{TeamID: team.ID, Name: "Bambang", Position: "penyerang", JerseyNumber: 20}
+	if err := db.Create(&player).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up with existing rows: %v", err)
+	}
+
+	var count int64
+	db.Model(&model.Player{}).Where("team_id = ?", team.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("players after rebuild = %d, want 1", count)
+	}
+
+	orphan := model.Player{TeamID: team.ID + 100, Name: "Orphan", Position: "bertahan", JerseyNumber: 4}
+	if err := db.Create(&orphan).Error; err == nil {
+		t.Error("expected a foreign key error for a player without a team")
+	}
+
+	match := model.Match{KickoffAt: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), TimeZone: "UTC", HomeTeamID: team.ID, AwayTeamID: team.ID}
+	if err := db.Create(&match).Error; err == nil {
+		t.Error("expected a check constraint error for a team playing itself")
+	}
+}
+
+func TestMigratorConvertsKickoffs(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrator, err := database.NewMigrator(db, jakarta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.To(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	home, away := model.Team{Name: "Persib", FoundedYear: 1933}, model.Team{Name: "Persija", FoundedYear: 1928}
+	db.Create(&home)
+	db.Create(&away)
+	for _, row := range [][]any{{1, "2026-05-10", "19:00"}, {2, "2026-05-17", "15:30:00"}, {3, "2026-05-24", "20:00"}, {4, "2026-05-31", "21:15:30"}} {
+		err := db.Exec("INSERT INTO matches (id, match_date, match_time, home_team_id, away_team_id) VALUES (?, ?, ?, ?, ?)", append(row, home.ID, away.ID)...).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	var matches []model.Match
+	if err := db.Order("id").Find(&matches).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 4 {
+		t.Fatalf("got %d matches after up, want 4", len(matches))
+	}
+	want := []time.Time{
+		time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 17, 8, 30, 0, 0, time.UTC),
+		time.Date(2026, 5, 24, 13, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 14, 15, 30, 0, time.UTC),
+	}
+	for i, m := range matches {
+		if !m.KickoffAt.Equal(want[i]) || m.TimeZone != "Asia/Jakarta" {
+			t.Errorf("match %d kicks off at %v in %q, want %v in Asia/Jakarta", m.ID, m.KickoffAt.UTC(), m.TimeZone, want[i])
+		}
+	}
+
+	if err := migrator.To(ctx, 8); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	for id, want := range map[int]string{2: "2026-05-17 15:30", 4: "2026-05-31 21:15:30"} {
+		var date, clock string
+		db.Raw("SELECT match_date, match_time FROM matches WHERE id = ?", id).Row().Scan(&date, &clock)
+		if got := date + " " + clock; got != want {
+			t.Errorf("match %d after down is at %q, want %q", id, got, want)
+		}
+	}
+
+	// Seconds survive a round trip
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up again: %v", err)
+	}
+	var again model.Match
+	if err := db.First(&again, 4).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !again.KickoffAt.Equal(want[3]) {
+		t.Errorf("match 4 kicks off at %v after down and up, want %v", again.KickoffAt.UTC(), want[3])
+	}
+
+	if err := migrator.To(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	for _, update := range []string{
+		"UPDATE matches SET match_time = 'sore' WHERE id = 3",
+		"UPDATE matches SET match_time = '20:00', match_date = 'tomorrow' WHERE id = 3",
+	} {
+		if err := db.Exec(update).Error; err != nil {
+			t.Fatal(err)
+		}
+		err := migrator.Up(ctx)
+		if err == nil || !strings.Contains(err.Error(), "match 3 ") {
+			t.Errorf("after %q, up = %v, want an error naming match 3", update, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    password   VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id           BIGSERIAL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    logo_url     VARCHAR(500),
+    founded_year BIGINT NOT NULL,
+    hq_address   TEXT,
+    hq_city      VARCHAR(255),
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    deleted_at   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_teams_deleted_at ON teams (deleted_at);
+
+CREATE TABLE IF NOT EXISTS players (
+    id            BIGSERIAL PRIMARY KEY,
+    team_id       BIGINT NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    height_cm     BIGINT,
+    weight_kg     BIGINT,
+    position      VARCHAR(20) NOT NULL,
+    jersey_number BIGINT NOT NULL,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ,
+    CONSTRAINT fk_teams_players FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+CREATE INDEX IF NOT EXISTS idx_players_deleted_at ON players (deleted_at);
+
+CREATE TABLE IF NOT EXISTS matches (
+    id           BIGSERIAL PRIMARY KEY,
+    match_date   VARCHAR(10) NOT NULL,
+    match_time   VARCHAR(8) NOT NULL,
+    home_team_id BIGINT NOT NULL,
+    away_team_id BIGINT NOT NULL,
+    home_score   BIGINT,
+    away_score   BIGINT,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    deleted_at   TIMESTAMPTZ,
+    CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id),
+    CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id)
+);
+CREATE INDEX IF NOT EXISTS idx_matches_deleted_at ON matches (deleted_at);
+
+CREATE TABLE IF NOT EXISTS goals (
+    id         BIGSERIAL PRIMARY KEY,
+    match_id   BIGINT NOT NULL,
+    player_id  BIGINT NOT NULL,
+    team_id    BIGINT NOT NULL,
+    minute     BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT fk_matches_goals FOREIGN KEY (match_id) REFERENCES matches (id),
+    CONSTRAINT fk_goals_player FOREIGN KEY (player_id) REFERENCES players (id),
+    CONSTRAINT fk_goals_team FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+CREATE INDEX IF NOT EXISTS idx_goals_deleted_at ON goals (deleted_at);
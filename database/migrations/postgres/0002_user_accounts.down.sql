@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_email_verification_token;
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verification_expiry;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verification_token;
+ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
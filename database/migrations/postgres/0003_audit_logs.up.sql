@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id         BIGSERIAL PRIMARY KEY,
+    entity     VARCHAR(64) NOT NULL,
+    entity_id  BIGINT,
+    action     VARCHAR(16) NOT NULL,
+    actor_id   BIGINT,
+    request_id VARCHAR(128),
+    before     TEXT,
+    after      TEXT,
+    changes    TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_id ON audit_logs (entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS match_result_revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    match_id   BIGINT NOT NULL,
+    revision   BIGINT NOT NULL,
+    home_score BIGINT NOT NULL,
+    away_score BIGINT NOT NULL,
+    goals      TEXT NOT NULL,
+    reason     TEXT NOT NULL,
+    changed_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_match_revision ON match_result_revisions (match_id, revision);
+
+CREATE TABLE IF NOT EXISTS result_submissions (
+    id           BIGSERIAL PRIMARY KEY,
+    match_id     BIGINT NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    home_score   BIGINT NOT NULL,
+    away_score   BIGINT NOT NULL,
+    goals        TEXT NOT NULL,
+    submitted_by BIGINT NOT NULL,
+    submitted_at TIMESTAMPTZ,
+    reviewed_by  BIGINT,
+    reviewed_at  TIMESTAMPTZ,
+    review_note  TEXT,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_result_submissions_match_id ON result_submissions (match_id);
+CREATE INDEX IF NOT EXISTS idx_result_submissions_status ON result_submissions (status);
+-- A match has at most one open submission
+CREATE UNIQUE INDEX IF NOT EXISTS idx_result_submissions_open ON result_submissions (match_id) WHERE status IN ('draft', 'submitted');
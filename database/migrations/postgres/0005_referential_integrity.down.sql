@@ -0,0 +1,18 @@
+ALTER TABLE result_submissions DROP CONSTRAINT IF EXISTS fk_result_submissions_match;
+ALTER TABLE match_result_revisions DROP CONSTRAINT IF EXISTS fk_match_result_revisions_match;
+
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS fk_goals_team;
+ALTER TABLE goals ADD CONSTRAINT fk_goals_team FOREIGN KEY (team_id) REFERENCES teams (id);
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS fk_goals_player;
+ALTER TABLE goals ADD CONSTRAINT fk_goals_player FOREIGN KEY (player_id) REFERENCES players (id);
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS fk_matches_goals;
+ALTER TABLE goals ADD CONSTRAINT fk_matches_goals FOREIGN KEY (match_id) REFERENCES matches (id);
+
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS chk_matches_distinct_teams;
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS fk_matches_away_team;
+ALTER TABLE matches ADD CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id);
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS fk_matches_home_team;
+ALTER TABLE matches ADD CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id);
+
+ALTER TABLE players DROP CONSTRAINT IF EXISTS fk_teams_players;
+ALTER TABLE players ADD CONSTRAINT fk_teams_players FOREIGN KEY (team_id) REFERENCES teams (id);
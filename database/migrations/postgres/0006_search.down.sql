@@ -0,0 +1,11 @@
+-- The unaccent and pg_trgm extensions are left installed, as other
+-- database objects may have come to depend on them.
+DROP INDEX IF EXISTS idx_players_name_trgm;
+DROP INDEX IF EXISTS idx_players_search;
+DROP INDEX IF EXISTS idx_teams_venue_trgm;
+DROP INDEX IF EXISTS idx_teams_venue_search;
+DROP INDEX IF EXISTS idx_teams_name_trgm;
+DROP INDEX IF EXISTS idx_teams_search;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS football_search;
+DROP FUNCTION IF EXISTS search_fold(text);
@@ -0,0 +1,31 @@
+-- Search folds accents away, so that "Jose" finds "José". unaccent() is
+-- only stable, and expression indexes need an immutable function, hence
+-- search_fold with the dictionary spelled out.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION search_fold(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT lower(public.unaccent('public.unaccent'::regdictionary, $1)) $$;
+
+CREATE TEXT SEARCH CONFIGURATION football_search (COPY = pg_catalog.simple);
+ALTER TEXT SEARCH CONFIGURATION football_search
+    ALTER MAPPING FOR hword, hword_part, word WITH public.unaccent, simple;
+
+-- The expressions below must match those in repository/search_repository.go
+-- for the planner to use the indexes.
+CREATE INDEX idx_teams_search ON teams USING gin (
+    (setweight(to_tsvector('football_search', name), 'A') ||
+     setweight(to_tsvector('football_search', coalesce(hq_city, '')), 'B'))
+) WHERE deleted_at IS NULL;
+CREATE INDEX idx_teams_name_trgm ON teams USING gin (search_fold(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_teams_venue_search ON teams USING gin (
+    to_tsvector('football_search', coalesce(hq_address, ''))
+) WHERE deleted_at IS NULL;
+CREATE INDEX idx_teams_venue_trgm ON teams USING gin (search_fold(coalesce(hq_address, '')) gin_trgm_ops) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_players_search ON players USING gin (
+    to_tsvector('football_search', name)
+) WHERE deleted_at IS NULL;
+CREATE INDEX idx_players_name_trgm ON players USING gin (search_fold(name) gin_trgm_ops) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS competition VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN IF EXISTS time_zone;
+ALTER TABLE matches DROP COLUMN IF EXISTS kickoff_at;
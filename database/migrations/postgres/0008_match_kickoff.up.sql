@@ -0,0 +1,4 @@
+-- kickoff_at and time_zone are filled in from match_date and match_time by
+-- the migrator, which reads them in the configured database time zone.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS kickoff_at TIMESTAMPTZ;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT '';
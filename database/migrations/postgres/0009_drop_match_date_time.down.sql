@@ -0,0 +1,7 @@
+-- match_date and match_time are filled in from the kickoff by the
+-- migrator, in the time zone of each match.
+DROP INDEX IF EXISTS idx_matches_kickoff_at;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS match_date VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS match_time VARCHAR(8) NOT NULL DEFAULT '';
+ALTER TABLE matches ALTER COLUMN time_zone SET DEFAULT '';
+ALTER TABLE matches ALTER COLUMN kickoff_at DROP NOT NULL;
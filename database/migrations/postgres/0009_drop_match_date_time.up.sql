@@ -0,0 +1,5 @@
+ALTER TABLE matches ALTER COLUMN kickoff_at SET NOT NULL;
+ALTER TABLE matches ALTER COLUMN time_zone DROP DEFAULT;
+ALTER TABLE matches DROP COLUMN IF EXISTS match_date;
+ALTER TABLE matches DROP COLUMN IF EXISTS match_time;
+CREATE INDEX IF NOT EXISTS idx_matches_kickoff_at ON matches (kickoff_at);
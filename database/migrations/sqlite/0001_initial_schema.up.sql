@@ -0,0 +1,70 @@
+CREATE TABLE users (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       VARCHAR(255) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    password   VARCHAR(255) NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME
+);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE teams (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         VARCHAR(255) NOT NULL,
+    logo_url     VARCHAR(500),
+    founded_year INTEGER NOT NULL,
+    hq_address   TEXT,
+    hq_city      VARCHAR(255),
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    deleted_at   DATETIME
+);
+CREATE INDEX idx_teams_deleted_at ON teams (deleted_at);
+
+CREATE TABLE players (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    team_id       INTEGER NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    height_cm     INTEGER,
+    weight_kg     INTEGER,
+    position      VARCHAR(20) NOT NULL,
+    jersey_number INTEGER NOT NULL,
+    created_at    DATETIME,
+    updated_at    DATETIME,
+    deleted_at    DATETIME,
+    CONSTRAINT fk_teams_players FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+CREATE INDEX idx_players_deleted_at ON players (deleted_at);
+
+CREATE TABLE matches (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_date   VARCHAR(10) NOT NULL,
+    match_time   VARCHAR(8) NOT NULL,
+    home_team_id INTEGER NOT NULL,
+    away_team_id INTEGER NOT NULL,
+    home_score   INTEGER,
+    away_score   INTEGER,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    deleted_at   DATETIME,
+    CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id),
+    CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id)
+);
+CREATE INDEX idx_matches_deleted_at ON matches (deleted_at);
+
+CREATE TABLE goals (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id   INTEGER NOT NULL,
+    player_id  INTEGER NOT NULL,
+    team_id    INTEGER NOT NULL,
+    minute     INTEGER NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    CONSTRAINT fk_matches_goals FOREIGN KEY (match_id) REFERENCES matches (id),
+    CONSTRAINT fk_goals_player FOREIGN KEY (player_id) REFERENCES players (id),
+    CONSTRAINT fk_goals_team FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+CREATE INDEX idx_goals_deleted_at ON goals (deleted_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_email_verification_token;
+ALTER TABLE users DROP COLUMN token_version;
+ALTER TABLE users DROP COLUMN email_verification_expiry;
+ALTER TABLE users DROP COLUMN email_verification_token;
+ALTER TABLE users DROP COLUMN pending_email;
+ALTER TABLE users DROP COLUMN role;
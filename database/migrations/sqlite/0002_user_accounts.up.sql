@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'reporter';
+ALTER TABLE users ADD COLUMN pending_email VARCHAR(255);
+ALTER TABLE users ADD COLUMN email_verification_token VARCHAR(64);
+ALTER TABLE users ADD COLUMN email_verification_expiry DATETIME;
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX idx_users_email_verification_token ON users (email_verification_token);
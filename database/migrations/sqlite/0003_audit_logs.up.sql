@@ -0,0 +1,16 @@
+CREATE TABLE audit_logs (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity     VARCHAR(64) NOT NULL,
+    entity_id  INTEGER,
+    action     VARCHAR(16) NOT NULL,
+    actor_id   INTEGER,
+    request_id VARCHAR(128),
+    before     TEXT,
+    after      TEXT,
+    changes    TEXT,
+    created_at DATETIME
+);
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity);
+CREATE INDEX idx_audit_logs_entity_id ON audit_logs (entity_id);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
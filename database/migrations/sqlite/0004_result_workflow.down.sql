@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS result_submissions;
+DROP TABLE IF EXISTS match_result_revisions;
@@ -0,0 +1,34 @@
+CREATE TABLE match_result_revisions (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id   INTEGER NOT NULL,
+    revision   INTEGER NOT NULL,
+    home_score INTEGER NOT NULL,
+    away_score INTEGER NOT NULL,
+    goals      TEXT NOT NULL,
+    reason     TEXT NOT NULL,
+    changed_by INTEGER NOT NULL,
+    created_at DATETIME,
+    CONSTRAINT fk_match_result_revisions_match FOREIGN KEY (match_id) REFERENCES matches (id)
+);
+CREATE UNIQUE INDEX idx_match_revision ON match_result_revisions (match_id, revision);
+
+CREATE TABLE result_submissions (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id     INTEGER NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    home_score   INTEGER NOT NULL,
+    away_score   INTEGER NOT NULL,
+    goals        TEXT NOT NULL,
+    submitted_by INTEGER NOT NULL,
+    submitted_at DATETIME,
+    reviewed_by  INTEGER,
+    reviewed_at  DATETIME,
+    review_note  TEXT,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    CONSTRAINT fk_result_submissions_match FOREIGN KEY (match_id) REFERENCES matches (id)
+);
+CREATE INDEX idx_result_submissions_match_id ON result_submissions (match_id);
+CREATE INDEX idx_result_submissions_status ON result_submissions (status);
+-- A match has at most one open submission
+CREATE UNIQUE INDEX idx_result_submissions_open ON result_submissions (match_id) WHERE status IN ('draft', 'submitted');
@@ -0,0 +1,98 @@
+-- SQLite cannot alter constraints in place, so each table is rebuilt
+-- and its rows copied over. The migrator runs this with foreign key
+-- enforcement off and checks every reference before committing.
+
+CREATE TABLE players_new (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    team_id       INTEGER NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    height_cm     INTEGER,
+    weight_kg     INTEGER,
+    position      VARCHAR(20) NOT NULL,
+    jersey_number INTEGER NOT NULL,
+    created_at    DATETIME,
+    updated_at    DATETIME,
+    deleted_at    DATETIME,
+    CONSTRAINT fk_teams_players FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+INSERT INTO players_new SELECT * FROM players;
+DROP TABLE players;
+ALTER TABLE players_new RENAME TO players;
+CREATE INDEX idx_players_deleted_at ON players (deleted_at);
+
+CREATE TABLE matches_new (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_date   VARCHAR(10) NOT NULL,
+    match_time   VARCHAR(8) NOT NULL,
+    home_team_id INTEGER NOT NULL,
+    away_team_id INTEGER NOT NULL,
+    home_score   INTEGER,
+    away_score   INTEGER,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    deleted_at   DATETIME,
+    CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id),
+    CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id)
+);
+INSERT INTO matches_new SELECT * FROM matches;
+DROP TABLE matches;
+ALTER TABLE matches_new RENAME TO matches;
+CREATE INDEX idx_matches_deleted_at ON matches (deleted_at);
+
+CREATE TABLE goals_new (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id   INTEGER NOT NULL,
+    player_id  INTEGER NOT NULL,
+    team_id    INTEGER NOT NULL,
+    minute     INTEGER NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    CONSTRAINT fk_matches_goals FOREIGN KEY (match_id) REFERENCES matches (id),
+    CONSTRAINT fk_goals_player FOREIGN KEY (player_id) REFERENCES players (id),
+    CONSTRAINT fk_goals_team FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+INSERT INTO goals_new SELECT * FROM goals;
+DROP TABLE goals;
+ALTER TABLE goals_new RENAME TO goals;
+CREATE INDEX idx_goals_deleted_at ON goals (deleted_at);
+
+CREATE TABLE match_result_revisions_new (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id   INTEGER NOT NULL,
+    revision   INTEGER NOT NULL,
+    home_score INTEGER NOT NULL,
+    away_score INTEGER NOT NULL,
+    goals      TEXT NOT NULL,
+    reason     TEXT NOT NULL,
+    changed_by INTEGER NOT NULL,
+    created_at DATETIME,
+    CONSTRAINT fk_match_result_revisions_match FOREIGN KEY (match_id) REFERENCES matches (id)
+);
+INSERT INTO match_result_revisions_new SELECT * FROM match_result_revisions;
+DROP TABLE match_result_revisions;
+ALTER TABLE match_result_revisions_new RENAME TO match_result_revisions;
+CREATE UNIQUE INDEX idx_match_revision ON match_result_revisions (match_id, revision);
+
+CREATE TABLE result_submissions_new (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_id     INTEGER NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    home_score   INTEGER NOT NULL,
+    away_score   INTEGER NOT NULL,
+    goals        TEXT NOT NULL,
+    submitted_by INTEGER NOT NULL,
+    submitted_at DATETIME,
+    reviewed_by  INTEGER,
+    reviewed_at  DATETIME,
+    review_note  TEXT,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    CONSTRAINT fk_result_submissions_match FOREIGN KEY (match_id) REFERENCES matches (id)
+);
+INSERT INTO result_submissions_new SELECT * FROM result_submissions;
+DROP TABLE result_submissions;
+ALTER TABLE result_submissions_new RENAME TO result_submissions;
+CREATE INDEX idx_result_submissions_match_id ON result_submissions (match_id);
+CREATE INDEX idx_result_submissions_status ON result_submissions (status);
+CREATE UNIQUE INDEX idx_result_submissions_open ON result_submissions (match_id) WHERE status IN ('draft', 'submitted');
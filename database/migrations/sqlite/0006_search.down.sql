@@ -0,0 +1 @@
+-- Nothing to undo, see 0006_search.up.sql.
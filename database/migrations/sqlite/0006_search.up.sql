@@ -0,0 +1,3 @@
+-- SQLite has no counterpart to the Postgres search configuration and
+-- indexes: search falls back to LIKE, which scans the tables. This
+-- migration only keeps the versions of both dialects in step.
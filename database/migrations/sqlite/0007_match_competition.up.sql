@@ -0,0 +1 @@
+ALTER TABLE matches ADD COLUMN competition VARCHAR(100) NOT NULL DEFAULT '';
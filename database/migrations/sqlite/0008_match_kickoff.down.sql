@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN time_zone;
+ALTER TABLE matches DROP COLUMN kickoff_at;
@@ -0,0 +1,4 @@
+-- kickoff_at and time_zone are filled in from match_date and match_time by
+-- the migrator, which reads them in the configured database time zone.
+ALTER TABLE matches ADD COLUMN kickoff_at DATETIME;
+ALTER TABLE matches ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '';
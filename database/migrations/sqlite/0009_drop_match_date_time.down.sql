@@ -0,0 +1,26 @@
+-- match_date and match_time are filled in from the kickoff by the
+-- migrator, in the time zone of each match.
+
+CREATE TABLE matches_new (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    match_date   VARCHAR(10) NOT NULL DEFAULT '',
+    match_time   VARCHAR(8) NOT NULL DEFAULT '',
+    home_team_id INTEGER NOT NULL,
+    away_team_id INTEGER NOT NULL,
+    home_score   INTEGER,
+    away_score   INTEGER,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    deleted_at   DATETIME,
+    competition  VARCHAR(100) NOT NULL DEFAULT '',
+    kickoff_at   DATETIME,
+    time_zone    VARCHAR(64) NOT NULL DEFAULT '',
+    CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    CONSTRAINT chk_matches_distinct_teams CHECK (home_team_id <> away_team_id)
+);
+INSERT INTO matches_new (id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, deleted_at, competition, kickoff_at, time_zone)
+SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, deleted_at, competition, kickoff_at, time_zone FROM matches;
+DROP TABLE matches;
+ALTER TABLE matches_new RENAME TO matches;
+CREATE INDEX idx_matches_deleted_at ON matches (deleted_at);
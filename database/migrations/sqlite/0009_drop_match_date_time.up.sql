@@ -0,0 +1,25 @@
+-- SQLite cannot make a column NOT NULL in place, so the table is rebuilt
+-- and its rows copied over.
+
+CREATE TABLE matches_new (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    kickoff_at   DATETIME NOT NULL,
+    time_zone    VARCHAR(64) NOT NULL,
+    competition  VARCHAR(100) NOT NULL DEFAULT '',
+    home_team_id INTEGER NOT NULL,
+    away_team_id INTEGER NOT NULL,
+    home_score   INTEGER,
+    away_score   INTEGER,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    deleted_at   DATETIME,
+    CONSTRAINT fk_matches_home_team FOREIGN KEY (home_team_id) REFERENCES teams (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    CONSTRAINT fk_matches_away_team FOREIGN KEY (away_team_id) REFERENCES teams (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    CONSTRAINT chk_matches_distinct_teams CHECK (home_team_id <> away_team_id)
+);
+INSERT INTO matches_new (id, kickoff_at, time_zone, competition, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, deleted_at)
+SELECT id, kickoff_at, time_zone, competition, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, deleted_at FROM matches;
+DROP TABLE matches;
+ALTER TABLE matches_new RENAME TO matches;
+CREATE INDEX idx_matches_deleted_at ON matches (deleted_at);
+CREATE INDEX idx_matches_kickoff_at ON matches (kickoff_at);
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/pranotoism/football-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// TracingPlugin wraps every GORM operation in a client span that is a child
+// of the span in the statement context, recording the SQL with its
+// placeholders, the table and the affected row count.
+type TracingPlugin struct{}
+
+func NewTracingPlugin() *TracingPlugin {
+	return &TracingPlugin{}
+}
+
+func (p *TracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	type processor interface {
+		Register(name string, fn func(*gorm.DB)) error
+	}
+	register := func(operation string, before, after processor) error {
+		if err := before.Register("tracing:before_"+operation, p.start(operation)); err != nil {
+			return err
+		}
+		return after.Register("tracing:after_"+operation, p.end)
+	}
+
+	if err := register("create", cb.Create().Before("*"), cb.Create().After("*")); err != nil {
+		return err
+	}
+	if err := register("query", cb.Query().Before("*"), cb.Query().After("*")); err != nil {
+		return err
+	}
+	if err := register("update", cb.Update().Before("*"), cb.Update().After("*")); err != nil {
+		return err
+	}
+	if err := register("delete", cb.Delete().Before("*"), cb.Delete().After("*")); err != nil {
+		return err
+	}
+	if err := register("row", cb.Row().Before("*"), cb.Row().After("*")); err != nil {
+		return err
+	}
+	return register("raw", cb.Raw().Before("*"), cb.Raw().After("*"))
+}
+
+func (p *TracingPlugin) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Only trace queries that belong to a traced request.
+			return
+		}
+
+		name := "gorm." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", db.Dialector.Name()),
+				attribute.String("db.operation", operation),
+				attribute.String("db.sql.table", db.Statement.Table),
+			),
+		)
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (p *TracingPlugin) end(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingPluginRecordsChildSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db := databasetest.New(t)
+
+	// Queries outside a trace are not recorded.
+	db.Find(&[]model.Team{})
+	if n := len(recorder.Ended()); n != 0 {
+		t.Fatalf("%d spans recorded without a parent trace", n)
+	}
+
+	ctx, root := provider.Tracer("test").Start(context.Background(), "request")
+	db.WithContext(ctx).Find(&[]model.Team{})
+	db.WithContext(ctx).Exec("SELECT * FROM missing_table")
+	root.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(spans))
+	}
+	query, failed := spans[0], spans[1]
+	if query.Name() != "gorm.query teams" || query.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("query span %q has parent %v", query.Name(), query.Parent().SpanID())
+	}
+	if failed.Status().Code != codes.Error {
+		t.Errorf("failed statement span status = %v, want error", failed.Status())
+	}
+}
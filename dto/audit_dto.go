@@ -0,0 +1,10 @@
+package dto
+
+type AuditLogQuery struct {
+	Entity   string `form:"entity"`
+	EntityID uint   `form:"entity_id"`
+	ActorID  uint   `form:"actor_id"`
+	Action   string `form:"action" binding:"omitempty,oneof=create update delete"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+}
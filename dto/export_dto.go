@@ -0,0 +1,17 @@
+package dto
+
+// PlayerExportQuery filters an export of players, which covers every team
+// unless TeamID is set.
+type PlayerExportQuery struct {
+	TeamID   uint   `form:"team_id"`
+	Name     string `form:"name"`
+	Position string `form:"position" binding:"omitempty,oneof=penyerang gelandang bertahan penjaga_gawang"`
+	Sort     string `form:"sort"`
+}
+
+type GoalExportQuery struct {
+	MatchID  uint   `form:"match_id"`
+	TeamID   uint   `form:"team_id"`
+	PlayerID uint   `form:"player_id"`
+	Sort     string `form:"sort"`
+}
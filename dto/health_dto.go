@@ -0,0 +1,13 @@
+package dto
+
+type ReadinessResponse struct {
+	Checks map[string]string `json:"checks"`
+}
+
+type VersionResponse struct {
+	Commit                string `json:"commit"`
+	BuildTime             string `json:"build_time"`
+	GoVersion             string `json:"go_version"`
+	SchemaVersion         *int64 `json:"schema_version"`
+	ExpectedSchemaVersion int64  `json:"expected_schema_version"`
+}
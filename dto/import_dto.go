@@ -0,0 +1,13 @@
+package dto
+
+// ImportPlayerRow is a player to import, with the team it joins. Teams are
+// imported as CreateTeamRequest rows and fixtures as CreateMatchRequest
+// rows.
+type ImportPlayerRow struct {
+	TeamID       uint   `json:"team_id" binding:"required"`
+	Name         string `json:"name" binding:"required"`
+	HeightCM     int    `json:"height_cm"`
+	WeightKG     int    `json:"weight_kg"`
+	Position     string `json:"position" binding:"required,oneof=penyerang gelandang bertahan penjaga_gawang"`
+	JerseyNumber int    `json:"jersey_number" binding:"required,min=1,max=99"`
+}
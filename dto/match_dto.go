@@ -1,17 +1,36 @@
 package dto
 
+import "time"
+
+// CreateMatchRequest schedules a match. MatchDate and MatchTime are local
+// to TimeZone, the IANA time zone of the venue, which defaults to that of
+// the database.
 type CreateMatchRequest struct {
-	MatchDate  string `json:"match_date" binding:"required"`
-	MatchTime  string `json:"match_time" binding:"required"`
-	HomeTeamID uint   `json:"home_team_id" binding:"required"`
-	AwayTeamID uint   `json:"away_team_id" binding:"required"`
+	MatchDate   string `json:"match_date" binding:"required,datetime=2006-01-02"`
+	MatchTime   string `json:"match_time" binding:"required,kickoff_time"`
+	TimeZone    string `json:"time_zone" binding:"omitempty,timezone"`
+	HomeTeamID  uint   `json:"home_team_id" binding:"required"`
+	AwayTeamID  uint   `json:"away_team_id" binding:"required"`
+	Competition string `json:"competition" binding:"max=100"`
 }
 
+// UpdateMatchRequest changes the fields that are set. A new date, time or
+// time zone moves the kickoff, keeping the local values not given.
 type UpdateMatchRequest struct {
-	MatchDate  string `json:"match_date"`
-	MatchTime  string `json:"match_time"`
-	HomeTeamID uint   `json:"home_team_id"`
-	AwayTeamID uint   `json:"away_team_id"`
+	MatchDate   string `json:"match_date" binding:"omitempty,datetime=2006-01-02"`
+	MatchTime   string `json:"match_time" binding:"omitempty,kickoff_time"`
+	TimeZone    string `json:"time_zone" binding:"omitempty,timezone"`
+	HomeTeamID  uint   `json:"home_team_id"`
+	AwayTeamID  uint   `json:"away_team_id"`
+	Competition string `json:"competition" binding:"max=100"`
+}
+
+type MatchListQuery struct {
+	DateFrom string `form:"date_from" binding:"omitempty,datetime=2006-01-02"`
+	DateTo   string `form:"date_to" binding:"omitempty,datetime=2006-01-02"`
+	TeamID   uint   `form:"team_id"`
+	Played   *bool  `form:"played"`
+	Sort     string `form:"sort"`
 }
 
 type GoalInput struct {
@@ -23,5 +42,23 @@ type GoalInput struct {
 type ReportResultRequest struct {
 	HomeScore int         `json:"home_score" binding:"min=0"`
 	AwayScore int         `json:"away_score" binding:"min=0"`
+	Goals     []GoalInput `json:"goals" binding:"dive"`
+	Draft     bool        `json:"draft"`
+}
+
+type AmendResultRequest struct {
+	HomeScore int         `json:"home_score" binding:"min=0"`
+	AwayScore int         `json:"away_score" binding:"min=0"`
+	Goals     []GoalInput `json:"goals" binding:"dive"`
+	Reason    string      `json:"reason" binding:"required"`
+}
+
+type MatchResultRevision struct {
+	Revision  int         `json:"revision"`
+	HomeScore int         `json:"home_score"`
+	AwayScore int         `json:"away_score"`
 	Goals     []GoalInput `json:"goals"`
+	Reason    string      `json:"reason"`
+	ChangedBy uint        `json:"changed_by"`
+	ChangedAt time.Time   `json:"changed_at"`
 }
@@ -0,0 +1,11 @@
+package dto
+
+// Pagination selects a page of a list, by page number or, when Cursor is
+// set, by the cursor of a previous page. Lists paged by cursor are only
+// counted when IncludeTotal is set.
+type Pagination struct {
+	Page         int
+	PerPage      int
+	Cursor       string
+	IncludeTotal bool
+}
@@ -15,3 +15,9 @@ type UpdatePlayerRequest struct {
 	Position     string `json:"position" binding:"omitempty,oneof=penyerang gelandang bertahan penjaga_gawang"`
 	JerseyNumber int    `json:"jersey_number" binding:"omitempty,min=1,max=99"`
 }
+
+type PlayerListQuery struct {
+	Name     string `form:"name"`
+	Position string `form:"position" binding:"omitempty,oneof=penyerang gelandang bertahan penjaga_gawang"`
+	Sort     string `form:"sort"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type GoalDetail struct {
 	PlayerName string `json:"player_name"`
 	TeamName   string `json:"team_name"`
@@ -12,14 +14,20 @@ type TopScorer struct {
 }
 
 type TeamInfo struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	LogoURL string `json:"logo_url,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
 }
 
 type MatchReport struct {
 	MatchID            uint         `json:"match_id"`
+	KickoffAt          time.Time    `json:"kickoff_at"`
+	KickoffLocal       time.Time    `json:"kickoff_local"`
+	TimeZone           string       `json:"time_zone"`
 	MatchDate          string       `json:"match_date"`
 	MatchTime          string       `json:"match_time"`
+	Competition        string       `json:"competition,omitempty"`
 	HomeTeam           TeamInfo     `json:"home_team"`
 	AwayTeam           TeamInfo     `json:"away_team"`
 	HomeScore          int          `json:"home_score"`
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+type UpdateSubmissionRequest struct {
+	HomeScore int         `json:"home_score" binding:"min=0"`
+	AwayScore int         `json:"away_score" binding:"min=0"`
+	Goals     []GoalInput `json:"goals" binding:"dive"`
+}
+
+type ReviewSubmissionRequest struct {
+	Note string `json:"note"`
+}
+
+type ResultSubmission struct {
+	ID          uint        `json:"id"`
+	MatchID     uint        `json:"match_id"`
+	Status      string      `json:"status"`
+	HomeScore   int         `json:"home_score"`
+	AwayScore   int         `json:"away_score"`
+	Goals       []GoalInput `json:"goals"`
+	SubmittedBy uint        `json:"submitted_by"`
+	SubmittedAt *time.Time  `json:"submitted_at"`
+	ReviewedBy  *uint       `json:"reviewed_by"`
+	ReviewedAt  *time.Time  `json:"reviewed_at"`
+	ReviewNote  string      `json:"review_note"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type ResultData struct {
+	HomeScore int         `json:"home_score"`
+	AwayScore int         `json:"away_score"`
+	Goals     []GoalInput `json:"goals" binding:"dive"`
+}
+
+type SubmissionDiff struct {
+	SubmissionID uint        `json:"submission_id"`
+	MatchID      uint        `json:"match_id"`
+	Published    *ResultData `json:"published"`
+	Submitted    ResultData  `json:"submitted"`
+	ScoreChanged bool        `json:"score_changed"`
+	GoalsAdded   []GoalInput `json:"goals_added"`
+	GoalsRemoved []GoalInput `json:"goals_removed"`
+}
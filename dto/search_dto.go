@@ -0,0 +1,19 @@
+package dto
+
+type SearchQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Type  string `form:"type"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// SearchResult is a team, player or venue matching a search, with the
+// score it was ranked by. Venues are team headquarters, so their ID is the
+// team ID.
+type SearchResult struct {
+	Type   string  `json:"type"`
+	ID     uint    `json:"id"`
+	Name   string  `json:"name"`
+	Detail string  `json:"detail,omitempty"`
+	TeamID uint    `json:"team_id"`
+	Score  float64 `json:"score"`
+}
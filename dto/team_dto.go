@@ -15,3 +15,11 @@ type UpdateTeamRequest struct {
 	HQAddress   string `json:"hq_address"`
 	HQCity      string `json:"hq_city"`
 }
+
+type TeamListQuery struct {
+	Name        string `form:"name"`
+	City        string `form:"city"`
+	FoundedFrom int    `form:"founded_from"`
+	FoundedTo   int    `form:"founded_to"`
+	Sort        string `form:"sort"`
+}
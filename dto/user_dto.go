@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type UpdateProfileRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ProfileResponse struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	PendingEmail string    `json:"pending_email,omitempty"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+}
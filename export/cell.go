@@ -0,0 +1,45 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// cell returns v with pointers dereferenced, and nil for a nil pointer.
+func cell(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// defuse keeps spreadsheets from evaluating text that looks like a
+// formula, such as a team named "=HYPERLINK(...)", by prefixing a quote.
+func defuse(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+	started bool
+}
+
+func newCSVWriter(w io.Writer, columns []string) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), columns: columns}
+}
+
+func (c *csvWriter) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	return c.w.Write(c.columns)
+}
+
+func (c *csvWriter) Write(row []any) error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	record := make([]string, len(row))
+	for i, v := range row {
+		v = cell(v)
+		record[i] = text(v)
+		if _, ok := v.(string); ok {
+			record[i] = defuse(record[i])
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
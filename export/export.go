@@ -0,0 +1,92 @@
+// Package export writes tabular data as CSV, NDJSON or XLSX one row at a
+// time, so that exports of any size are streamed rather than built up in
+// memory.
+package export
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"strings"
+)
+
+const (
+	CSV    = "csv"
+	NDJSON = "ndjson"
+	XLSX   = "xlsx"
+)
+
+// Formats lists the supported formats, the first being the default.
+var Formats = []string{CSV, NDJSON, XLSX}
+
+var ErrUnsupportedFormat = errors.New("export format must be csv, ndjson or xlsx")
+
+var contentTypes = map[string]string{
+	CSV:    "text/csv; charset=utf-8",
+	NDJSON: "application/x-ndjson",
+	XLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// acceptTypes maps media types a client may ask for to formats.
+var acceptTypes = map[string]string{
+	"text/csv":             CSV,
+	"application/x-ndjson": NDJSON,
+	"application/jsonl":    NDJSON,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": XLSX,
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Negotiate picks the format of an export: param when given, otherwise the
+// first supported media type in the Accept header, defaulting to CSV.
+func Negotiate(param, accept string) (string, error) {
+	if param != "" {
+		if _, ok := contentTypes[param]; !ok {
+			return "", ErrUnsupportedFormat
+		}
+		return param, nil
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		if format, ok := acceptTypes[mediaType]; ok {
+			return format, nil
+		}
+	}
+	return CSV, nil
+}
+
+// Writer writes the rows of a table. The header is written with the first
+// row, or by Close when there are none, so that nothing reaches the
+// underlying writer before the first row is ready.
+type Writer interface {
+	Write(row []any) error
+	// Flush writes the rows buffered so far, e.g. after each batch, so a
+	// long export keeps reaching the client.
+	Flush() error
+	// Close writes anything still buffered. It does not close the
+	// underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer for a table with the given columns. Cells may
+// be strings, integers, floats, bools, time.Time, pointers to those, or nil
+// for an empty cell.
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w, columns), nil
+	case NDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case XLSX:
+		return newXLSXWriter(w, columns), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
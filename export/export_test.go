@@ -0,0 +1,174 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, format string, columns []string, rows ...[]any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	score := 2
+	got := write(t, CSV, []string{"name", "score", "played_at", "note"},
+		[]any{"=HYPERLINK(\"x\")", &score, time.Date(2026, 1, 10, 19, 0, 0, 0, time.FixedZone("WIB", 7*3600)), nil},
+		[]any{"Persib, Bandung", (*int)(nil), nil, "-1"},
+	)
+	want := "name,score,played_at,note\n" +
+		"\"'=HYPERLINK(\"\"x\"\")\",2,2026-01-10T12:00:00Z,\n" +
+		"\"Persib, Bandung\",,,'-1\n"
+	if string(got) != want {
+		t.Errorf("csv =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := write(t, CSV, []string{"id", "name"}); string(got) != "id,name\n" {
+		t.Errorf("empty csv = %q, want only the header", got)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	got := write(t, NDJSON, []string{"name", "id", "score"},
+		[]any{"=Persib", uint(1), nil},
+		[]any{"Persija", uint(2), 3},
+	)
+	want := `{"name":"=Persib","id":1,"score":null}` + "\n" + `{"name":"Persija","id":2,"score":3}` + "\n"
+	if string(got) != want {
+		t.Errorf("ndjson = %q, want %q", got, want)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	columns := make([]string, 28)
+	for i := range columns {
+		columns[i] = "c"
+	}
+	row := make([]any, 28)
+	row[0], row[1], row[2], row[27] = "Persib & <co>", 1933, true, "last"
+
+	data := write(t, XLSX, columns, row)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet []byte
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sheet, _ = io.ReadAll(r)
+		}
+	}
+
+	var ws struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(sheet, &ws); err != nil {
+		t.Fatalf("sheet does not parse: %v\n%s", err, sheet)
+	}
+	if len(ws.Rows) != 2 || len(ws.Rows[0].Cells) != 28 {
+		t.Fatalf("sheet has %d rows, want a header and one row:\n%s", len(ws.Rows), sheet)
+	}
+
+	var got []string
+	for _, c := range ws.Rows[1].Cells {
+		got = append(got, c.Ref+"="+c.Type+":"+c.Value+c.Inline)
+	}
+	want := "A2=inlineStr:Persib & <co> B2=:1933 C2=b:1 AB2=inlineStr:last"
+	if strings.Join(got, " ") != want {
+		t.Errorf("cells = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestNothingWrittenBeforeFirstRow(t *testing.T) {
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		if _, err := NewWriter(format, &buf, []string{"id"}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("%s wrote %d bytes before the first row", format, buf.Len())
+		}
+	}
+}
+
+func TestFlushWritesBufferedRows(t *testing.T) {
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		w, err := NewWriter(format, &buf, []string{"id", "name"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil || buf.Len() > 0 {
+			t.Errorf("%s flush before the first row wrote %d bytes, err %v", format, buf.Len(), err)
+		}
+
+		if err := w.Write([]any{1, "Persib"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		flushed := buf.Len()
+		if flushed == 0 {
+			t.Errorf("%s flush left the first row buffered", format)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() < flushed {
+			t.Errorf("%s close shrank the output", format)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		param, accept, want string
+	}{
+		{"", "", CSV},
+		{"", "*/*", CSV},
+		{"xlsx", "text/csv", XLSX},
+		{"", "application/json, application/x-ndjson", NDJSON},
+		{"", "text/csv;q=0, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", XLSX},
+	}
+	for _, tt := range tests {
+		got, err := Negotiate(tt.param, tt.accept)
+		if err != nil || got != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %q, %v, want %q", tt.param, tt.accept, got, err, tt.want)
+		}
+	}
+
+	if _, err := Negotiate("pdf", ""); err != ErrUnsupportedFormat {
+		t.Errorf("Negotiate(pdf) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
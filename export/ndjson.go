@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// ndjsonWriter writes each row as a JSON object on its own line, with its
+// keys in column order. It has no header.
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	keys [][]byte
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) *ndjsonWriter {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		keys[i], _ = json.Marshal(column)
+	}
+	return &ndjsonWriter{w: bufio.NewWriter(w), keys: keys}
+}
+
+func (n *ndjsonWriter) Write(row []any) error {
+	n.w.WriteByte('{')
+	for i, v := range row {
+		value, err := json.Marshal(cell(v))
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		n.w.Write(n.keys[i])
+		n.w.WriteByte(':')
+		n.w.Write(value)
+	}
+	_, err := n.w.WriteString("}\n")
+	return err
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
@@ -0,0 +1,152 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// The parts of a workbook with a single worksheet. Cells hold inline
+// strings, so no shared string table has to be built before the sheet can
+// be written.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter streams a worksheet into a zip archive. The compressed sheet is
+// written out as rows arrive; only the archive directory is kept until
+// Close.
+type xlsxWriter struct {
+	out     io.Writer
+	zw      *zip.Writer
+	sheet   io.Writer
+	columns []string
+	rows    int
+}
+
+func newXLSXWriter(w io.Writer, columns []string) *xlsxWriter {
+	return &xlsxWriter{out: w, columns: columns}
+}
+
+func (x *xlsxWriter) start() error {
+	if x.zw != nil {
+		return nil
+	}
+	x.zw = zip.NewWriter(x.out)
+	for _, part := range xlsxParts {
+		w, err := x.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := x.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	if _, err := io.WriteString(x.sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	header := make([]any, len(x.columns))
+	for i, column := range x.columns {
+		header[i] = column
+	}
+	return x.writeRow(header)
+}
+
+func (x *xlsxWriter) Write(row []any) error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	return x.writeRow(row)
+}
+
+func (x *xlsxWriter) writeRow(row []any) error {
+	x.rows++
+	n := strconv.Itoa(x.rows)
+	buf := []byte(`<row r="` + n + `">`)
+	for i, v := range row {
+		ref := columnName(i) + n
+		switch v := cell(v).(type) {
+		case nil:
+			continue
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			buf = append(buf, `<c r="`+ref+`"><v>`+text(v)+`</v></c>`...)
+		case bool:
+			value := "0"
+			if v {
+				value = "1"
+			}
+			buf = append(buf, `<c r="`+ref+`" t="b"><v>`+value+`</v></c>`...)
+		default:
+			buf = append(buf, `<c r="`+ref+`" t="inlineStr"><is><t xml:space="preserve">`...)
+			buf = appendEscaped(buf, text(v))
+			buf = append(buf, `</t></is></c>`...)
+		}
+	}
+	buf = append(buf, `</row>`...)
+	_, err := x.sheet.Write(buf)
+	return err
+}
+
+// Flush pushes the compressed rows so far to the underlying writer. The
+// archive is only complete once Close has written its directory.
+func (x *xlsxWriter) Flush() error {
+	if x.zw == nil {
+		return nil
+	}
+	return x.zw.Flush()
+}
+
+func (x *xlsxWriter) Close() error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
+
+// columnName returns the letters of the zero-based column i: A, B, ... Z,
+// AA, AB and so on.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+type byteWriter struct{ buf *[]byte }
+
+func (w byteWriter) Write(p []byte) (int, error) {
+	*w.buf = append(*w.buf, p...)
+	return len(p), nil
+}
+
+// appendEscaped appends s escaped for XML text, replacing characters XML
+// cannot hold.
+func appendEscaped(buf []byte, s string) []byte {
+	xml.EscapeText(byteWriter{&buf}, []byte(s))
+	return buf
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/pranotoism/football-go/config"
+	"github.com/pranotoism/football-go/database"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/export"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
+)
+
+const exportUsage = "usage: football-go export [--format csv|ndjson|xlsx] [--output FILE] matches|players|goals|reports [FILTER=VALUE ...]"
+
+// runExport implements the export subcommand. Filters are the query
+// parameters of the matching endpoint, e.g. team_id=3 or sort=-kickoff_at.
+// Without --output the export goes to stdout.
+func runExport(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, exportUsage) }
+	format := fs.String("format", "", "csv, ndjson or xlsx, by default taken from the output file extension or else csv")
+	output := fs.String("output", "", "file to write, stdout by default")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		log.Fatal(exportUsage)
+	}
+	kind := fs.Arg(0)
+
+	params := url.Values{}
+	for _, arg := range fs.Args()[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("filter %q must be written as FILTER=VALUE", arg)
+		}
+		params.Add(key, value)
+	}
+
+	if *format == "" && *output != "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+	}
+	if *format == "" {
+		*format = export.CSV
+	}
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database: ", err)
+	}
+	teamRepo := repository.NewTeamRepository(db)
+	playerRepo := repository.NewPlayerRepository(db)
+	matchRepo := repository.NewMatchRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	loc, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		log.Fatal("Failed to load time zone: ", err)
+	}
+	exportService := service.NewExportService(teamRepo, playerRepo, matchRepo, goalRepo, service.NewReportService(matchRepo), loc)
+
+	var write func(ctx context.Context, w io.Writer) error
+	switch kind {
+	case "matches":
+		var filter dto.MatchListQuery
+		bindFilter(params, &filter)
+		write = func(ctx context.Context, w io.Writer) error { return exportService.Matches(ctx, filter, *format, w) }
+	case "players":
+		var filter dto.PlayerExportQuery
+		bindFilter(params, &filter)
+		write = func(ctx context.Context, w io.Writer) error { return exportService.Players(ctx, filter, *format, w) }
+	case "goals":
+		var filter dto.GoalExportQuery
+		bindFilter(params, &filter)
+		write = func(ctx context.Context, w io.Writer) error { return exportService.Goals(ctx, filter, *format, w) }
+	case "reports":
+		var filter dto.MatchListQuery
+		bindFilter(params, &filter)
+		write = func(ctx context.Context, w io.Writer) error { return exportService.Reports(ctx, filter, *format, w) }
+	default:
+		log.Fatalf("cannot export %q, only matches, players, goals and reports", kind)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			log.Fatal("Failed to create export file: ", err)
+		}
+	}
+	bw := bufio.NewWriter(out)
+	err = write(context.Background(), bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if out != os.Stdout {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(*output)
+		}
+	}
+	if err != nil {
+		log.Fatal("Export failed: ", err)
+	}
+}
+
+// bindFilter binds params into filter as the export endpoints bind their
+// query string, and exits on invalid filters.
+func bindFilter(params url.Values, filter any) {
+	req := &http.Request{URL: &url.URL{RawQuery: params.Encode()}}
+	err := binding.Query.Bind(req, filter)
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		printRowErrors(service.FieldErrors(validationErrs))
+		log.Fatal("Invalid filters")
+	}
+	if err != nil {
+		log.Fatal("Invalid filters: ", err)
+	}
+}
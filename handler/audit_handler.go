@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+func (h *AuditHandler) FindAll(c *gin.Context) {
+	var query dto.AuditLogQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	page, perPage := getPagination(c)
+
+	logs, total, err := h.auditService.FindAll(c.Request.Context(), query, page, perPage)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Audit logs retrieved successfully", logs, util.PageMeta(page, perPage, total))
+}
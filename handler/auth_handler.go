@@ -19,14 +19,13 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	user, err := h.authService.Register(req)
+	user, err := h.authService.Register(c.Request.Context(), req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -39,14 +38,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	token, err := h.authService.Login(req)
+	token, err := h.authService.Login(c.Request.Context(), req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		c.Error(err)
 		return
 	}
 
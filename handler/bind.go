@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/pranotoism/football-go/service"
+)
+
+// Report fields by the name the client sent, not the Go field name.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(service.FieldName)
+		v.RegisterValidation("kickoff_time", service.KickoffTime)
+	}
+}
+
+// bindJSON binds the request body into obj. On failure it records a
+// validation error for ErrorHandler and reports false.
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		c.Error(bindingError(err))
+		return false
+	}
+	return true
+}
+
+func bindQuery(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		c.Error(bindingError(err))
+		return false
+	}
+	return true
+}
+
+// paramID parses the :id path parameter. entity names the record in the
+// error message.
+func paramID(c *gin.Context, entity string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.Error(service.ValidationError("invalid_id", "invalid "+entity+" ID", service.FieldError{
+			Field:   "id",
+			Code:    "invalid",
+			Message: "must be a positive integer",
+		}))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func bindingError(err error) error {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+		numErr         *strconv.NumError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		return service.ValidationError("invalid_fields", "request has invalid fields", service.FieldErrors(validationErrs)...)
+	case errors.As(err, &typeErr):
+		return service.ValidationError("invalid_fields", "request has invalid fields", service.FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: "must be of type " + typeErr.Type.String(),
+		})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return service.ValidationError("malformed_json", "request body is not valid JSON")
+	case errors.Is(err, io.EOF):
+		return service.ValidationError("empty_body", "request body is empty")
+	case errors.As(err, &numErr):
+		return service.ValidationError("invalid_query", fmt.Sprintf("%q is not a valid number", numErr.Num))
+	default:
+		return service.ValidationError("invalid_request", err.Error())
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/service"
+)
+
+func bindRequest(t *testing.T, body string, obj any) *service.Error {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	if bindJSON(c, obj) {
+		return nil
+	}
+	var err *service.Error
+	if !errors.As(c.Errors.Last().Err, &err) {
+		t.Fatalf("expected a service error, got %v", c.Errors.Last().Err)
+	}
+	return err
+}
+
+func TestBindJSONReportsFieldErrors(t *testing.T) {
+	var req dto.CreateMatchRequest
+	err := bindRequest(t, `{"match_date":"2026-01-01","home_team_id":1,"away_team_id":2}`, &req)
+	if err == nil {
+		t.Fatal("expected a binding error")
+	}
+	if err.Kind != service.KindValidation || err.Code != "invalid_fields" {
+		t.Fatalf("expected an invalid_fields validation error, got %+v", err)
+	}
+
+	want := []service.FieldError{
+		{Field: "match_time", Code: "required", Message: "is required"},
+	}
+	if !slices.Equal(err.Fields, want) {
+		t.Fatalf("expected fields %+v, got %+v", want, err.Fields)
+	}
+}
+
+func TestBindJSONKickoffTime(t *testing.T) {
+	for clock, valid := range map[string]bool{"19:00": true, "19:00:00": true, "7:00 PM": false, "25:99": false} {
+		var req dto.CreateMatchRequest
+		err := bindRequest(t, `{"match_date":"2026-01-01","match_time":"`+clock+`","home_team_id":1,"away_team_id":2}`, &req)
+		if valid && err != nil {
+			t.Errorf("match_time %q: %+v", clock, err)
+		}
+		want := []service.FieldError{{Field: "match_time", Code: "kickoff_time", Message: "must be an HH:MM or HH:MM:SS time"}}
+		if !valid && (err == nil || !slices.Equal(err.Fields, want)) {
+			t.Errorf("match_time %q: expected fields %+v, got %+v", clock, want, err)
+		}
+	}
+}
+
+func TestBindJSONReportsNestedFields(t *testing.T) {
+	var req dto.AmendResultRequest
+	err := bindRequest(t, `{"home_score":-1,"away_score":0,"goals":[{"player_id":1,"team_id":1,"minute":0}]}`, &req)
+	if err == nil {
+		t.Fatal("expected a binding error")
+	}
+
+	want := []service.FieldError{
+		{Field: "home_score", Code: "min", Message: "must be at least 0"},
+		{Field: "goals[0].minute", Code: "required", Message: "is required"},
+		{Field: "reason", Code: "required", Message: "is required"},
+	}
+	if !slices.Equal(err.Fields, want) {
+		t.Fatalf("expected fields %+v, got %+v", want, err.Fields)
+	}
+}
+
+func TestBindJSONChecksEveryGoal(t *testing.T) {
+	want := []service.FieldError{
+		{Field: "goals[1].minute", Code: "required", Message: "is required"},
+		{Field: "goals[1].player_id", Code: "required", Message: "is required"},
+	}
+	for name, obj := range map[string]any{"report": &dto.ReportResultRequest{}, "update": &dto.UpdateSubmissionRequest{}} {
+		err := bindRequest(t, `{"home_score":2,"away_score":0,"goals":[{"player_id":9,"team_id":1,"minute":12},{"team_id":1,"minute":0}]}`, obj)
+		if err == nil {
+			t.Errorf("%s: expected a binding error", name)
+			continue
+		}
+		slices.SortFunc(err.Fields, func(a, b service.FieldError) int { return strings.Compare(a.Field, b.Field) })
+		if !slices.Equal(err.Fields, want) {
+			t.Errorf("%s: expected fields %+v, got %+v", name, want, err.Fields)
+		}
+	}
+}
+
+func TestBindJSONReportsTypeAndSyntaxErrors(t *testing.T) {
+	var req dto.CreateTeamRequest
+	err := bindRequest(t, `{"name":"Persib","founded_year":"1933"}`, &req)
+	want := []service.FieldError{{Field: "founded_year", Code: "type", Message: "must be of type int"}}
+	if err == nil || !slices.Equal(err.Fields, want) {
+		t.Fatalf("expected fields %+v, got %+v", want, err)
+	}
+
+	err = bindRequest(t, `{"name":`, &req)
+	if err == nil || err.Code != "malformed_json" {
+		t.Fatalf("expected malformed_json, got %+v", err)
+	}
+
+	err = bindRequest(t, ``, &req)
+	if err == nil || err.Code != "empty_body" {
+		t.Fatalf("expected empty_body, got %+v", err)
+	}
+}
+
+func TestParamIDRejectsNonPositive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for param, want := range map[string]uint{"7": 7, "0": 0, "-1": 0, "abc": 0, "4294967296": 0} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Params = gin.Params{{Key: "id", Value: param}}
+
+		id, ok := paramID(c, "team")
+		if ok != (want != 0) || id != want {
+			t.Errorf("paramID(%q) = %d, %v", param, id, ok)
+		}
+		if !ok {
+			var err *service.Error
+			if !errors.As(c.Errors.Last().Err, &err) || err.Code != "invalid_id" {
+				t.Errorf("paramID(%q) recorded %v, want invalid_id", param, c.Errors.Last())
+			}
+		}
+	}
+}
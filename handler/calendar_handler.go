@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/ical"
+	"github.com/pranotoism/football-go/service"
+)
+
+type CalendarHandler struct {
+	calendarService *service.CalendarService
+}
+
+func NewCalendarHandler(calendarService *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// Fixtures serves the fixtures of all teams, e.g. of a season given by
+// date_from and date_to.
+func (h *CalendarHandler) Fixtures(c *gin.Context) {
+	var filter dto.MatchListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+
+	cal, err := h.calendarService.Fixtures(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeCalendar(c, cal)
+}
+
+func (h *CalendarHandler) TeamFixtures(c *gin.Context) {
+	id, ok := paramID(c, "team")
+	if !ok {
+		return
+	}
+	var filter dto.MatchListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+
+	cal, err := h.calendarService.TeamFixtures(c.Request.Context(), id, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeCalendar(c, cal)
+}
+
+func writeCalendar(c *gin.Context, cal *ical.Calendar) {
+	c.Header("Content-Type", ical.ContentType)
+	c.Status(http.StatusOK)
+	if err := cal.Write(c.Writer); err != nil {
+		c.Error(err)
+	}
+}
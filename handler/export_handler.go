@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/export"
+	"github.com/pranotoism/football-go/service"
+)
+
+type ExportHandler struct {
+	exportService *service.ExportService
+	writeTimeout  time.Duration
+}
+
+// NewExportHandler returns a handler whose exports may take longer than the
+// server write timeout, as long as each write finishes within writeTimeout.
+func NewExportHandler(exportService *service.ExportService, writeTimeout time.Duration) *ExportHandler {
+	return &ExportHandler{exportService: exportService, writeTimeout: writeTimeout}
+}
+
+func (h *ExportHandler) Matches(c *gin.Context) {
+	var filter dto.MatchListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+	stream(c, h.writeTimeout, "matches", func(ctx context.Context, format string, w io.Writer) error {
+		return h.exportService.Matches(ctx, filter, format, w)
+	})
+}
+
+func (h *ExportHandler) Players(c *gin.Context) {
+	var filter dto.PlayerExportQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+	stream(c, h.writeTimeout, "players", func(ctx context.Context, format string, w io.Writer) error {
+		return h.exportService.Players(ctx, filter, format, w)
+	})
+}
+
+func (h *ExportHandler) Goals(c *gin.Context) {
+	var filter dto.GoalExportQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+	stream(c, h.writeTimeout, "goals", func(ctx context.Context, format string, w io.Writer) error {
+		return h.exportService.Goals(ctx, filter, format, w)
+	})
+}
+
+func (h *ExportHandler) Reports(c *gin.Context) {
+	var filter dto.MatchListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+	stream(c, h.writeTimeout, "reports", func(ctx context.Context, format string, w io.Writer) error {
+		return h.exportService.Reports(ctx, filter, format, w)
+	})
+}
+
+// stream writes an export in the format chosen by the format parameter or
+// else the Accept header. Headers are only sent with the first bytes of the
+// export, so errors found before then still get a problem response. The
+// write deadline is pushed back by timeout on every write, so a stalled
+// client is still cut off but a long export is not.
+func stream(c *gin.Context, timeout time.Duration, name string, write func(ctx context.Context, format string, w io.Writer) error) {
+	format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		c.Error(service.ErrInvalidExportFormat)
+		return
+	}
+
+	w := &attachmentWriter{
+		c:           c,
+		rc:          http.NewResponseController(c.Writer),
+		timeout:     timeout,
+		contentType: export.ContentType(format),
+		filename:    name + "." + format,
+	}
+	if err := write(c.Request.Context(), format, w); err != nil {
+		c.Error(err)
+	}
+}
+
+// attachmentWriter starts a 200 response with the headers of a file
+// download on the first write.
+type attachmentWriter struct {
+	c           *gin.Context
+	rc          *http.ResponseController
+	timeout     time.Duration
+	contentType string
+	filename    string
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	err := w.rc.SetWriteDeadline(time.Now().Add(w.timeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return 0, err
+	}
+	if !w.c.Writer.Written() {
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// Flush sends what has been written so far. Before the first write there is
+// nothing to send, and flushing would commit the status early.
+func (w *attachmentWriter) Flush() error {
+	if !w.c.Writer.Written() {
+		return nil
+	}
+	err := w.rc.Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamOutlivesWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		timeout = 100 * time.Millisecond
+		batches = 6
+	)
+
+	r := gin.New()
+	r.GET("/export", func(c *gin.Context) {
+		stream(c, timeout, "slow", func(ctx context.Context, format string, w io.Writer) error {
+			for i := range batches {
+				if _, err := fmt.Fprintf(w, "row %d\n", i); err != nil {
+					return err
+				}
+				if err := w.(interface{ Flush() error }).Flush(); err != nil {
+					return err
+				}
+				// Each batch is quick, but together they take well over the
+				// server write timeout
+				time.Sleep(timeout / 2)
+			}
+			return nil
+		})
+	})
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = timeout
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("export cut off after %q: %v", body, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Errorf("got %d %s, want a CSV download", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if got := strings.Count(string(body), "\n"); got != batches {
+		t.Errorf("got %d rows, want %d:\n%s", got, batches, body)
+	}
+}
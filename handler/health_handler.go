@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Live reports that the process is up. It checks no dependencies, so a
+// database outage does not get the process restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	util.SuccessResponse(c, http.StatusOK, "alive", nil)
+}
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	ready, checks := h.healthService.Ready(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, util.Response{Status: "error", Message: "not ready", Data: checks})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, "ready", checks)
+}
+
+func (h *HealthHandler) Version(c *gin.Context) {
+	util.SuccessResponse(c, http.StatusOK, "version retrieved successfully", h.healthService.Version(c.Request.Context()))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type ImportHandler struct {
+	importService *service.ImportService
+	maxBytes      int64
+}
+
+func NewImportHandler(importService *service.ImportService, maxBytes int64) *ImportHandler {
+	return &ImportHandler{importService: importService, maxBytes: maxBytes}
+}
+
+// Import creates the rows of the request body, a CSV file or JSON array
+// chosen by the format parameter or else the content type. Bodies over
+// maxBytes are refused as they are read.
+func (h *ImportHandler) Import(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = service.ImportCSV
+		case "application/json":
+			format = service.ImportJSON
+		}
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes)
+	result, err := h.importService.Import(c.Request.Context(), c.Param("kind"), format, body, dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if dryRun {
+		util.SuccessResponse(c, http.StatusOK, "Import checked, nothing was imported", result)
+		return
+	}
+	util.SuccessResponse(c, http.StatusCreated, "Rows imported successfully", result)
+}
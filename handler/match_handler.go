@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"math"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pranotoism/football-go/dto"
@@ -21,14 +19,13 @@ func NewMatchHandler(matchService *service.MatchService) *MatchHandler {
 
 func (h *MatchHandler) Create(c *gin.Context) {
 	var req dto.CreateMatchRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	match, err := h.matchService.Create(req)
+	match, err := h.matchService.Create(c.Request.Context(), req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -36,32 +33,31 @@ func (h *MatchHandler) Create(c *gin.Context) {
 }
 
 func (h *MatchHandler) FindAll(c *gin.Context) {
-	page, perPage := getPagination(c)
+	var filter dto.MatchListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
 
-	matches, total, err := h.matchService.FindAll(page, perPage)
+	p := getListPagination(c)
+
+	matches, err := h.matchService.FindAll(c.Request.Context(), filter, p)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	util.PaginatedSuccessResponse(c, http.StatusOK, "Matches retrieved successfully", matches, util.Meta{
-		Page:       page,
-		PerPage:    perPage,
-		Total:      total,
-		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
-	})
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Matches retrieved successfully", matches.Items, listMeta(p, matches.Total, matches.Next, matches.Prev))
 }
 
 func (h *MatchHandler) FindByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid match ID")
+	id, ok := paramID(c, "match")
+	if !ok {
 		return
 	}
 
-	match, err := h.matchService.FindByID(uint(id))
+	match, err := h.matchService.FindByID(c.Request.Context(), id)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -69,21 +65,19 @@ func (h *MatchHandler) FindByID(c *gin.Context) {
 }
 
 func (h *MatchHandler) Update(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid match ID")
+	id, ok := paramID(c, "match")
+	if !ok {
 		return
 	}
 
 	var req dto.UpdateMatchRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	match, err := h.matchService.Update(uint(id), req)
+	match, err := h.matchService.Update(c.Request.Context(), id, req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -91,38 +85,50 @@ func (h *MatchHandler) Update(c *gin.Context) {
 }
 
 func (h *MatchHandler) Delete(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid match ID")
+	id, ok := paramID(c, "match")
+	if !ok {
 		return
 	}
 
-	if err := h.matchService.Delete(uint(id)); err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+	if err := h.matchService.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
 		return
 	}
 
 	util.SuccessResponse(c, http.StatusOK, "Match deleted successfully", nil)
 }
 
-func (h *MatchHandler) ReportResult(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *MatchHandler) AmendResult(c *gin.Context) {
+	id, ok := paramID(c, "match")
+	if !ok {
+		return
+	}
+
+	var req dto.AmendResultRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	match, err := h.matchService.AmendResult(c.Request.Context(), id, c.GetUint("userID"), req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid match ID")
+		c.Error(err)
 		return
 	}
 
-	var req dto.ReportResultRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	util.SuccessResponse(c, http.StatusOK, "Match result amended successfully", match)
+}
+
+func (h *MatchHandler) FindRevisions(c *gin.Context) {
+	id, ok := paramID(c, "match")
+	if !ok {
 		return
 	}
 
-	match, err := h.matchService.ReportResult(uint(id), req)
+	revisions, err := h.matchService.FindRevisions(c.Request.Context(), id)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
-	util.SuccessResponse(c, http.StatusOK, "Match result reported successfully", match)
+	util.SuccessResponse(c, http.StatusOK, "Match result revisions retrieved successfully", revisions)
 }
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"math"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pranotoism/football-go/dto"
@@ -20,25 +18,19 @@ func NewPlayerHandler(playerService *service.PlayerService) *PlayerHandler {
 }
 
 func (h *PlayerHandler) Create(c *gin.Context) {
-	teamID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid team ID")
+	teamID, ok := paramID(c, "team")
+	if !ok {
 		return
 	}
 
 	var req dto.CreatePlayerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	player, err := h.playerService.Create(uint(teamID), req)
+	player, err := h.playerService.Create(c.Request.Context(), teamID, req)
 	if err != nil {
-		if err.Error() == "team not found" {
-			util.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -46,42 +38,36 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 }
 
 func (h *PlayerHandler) FindByTeam(c *gin.Context) {
-	teamID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid team ID")
+	teamID, ok := paramID(c, "team")
+	if !ok {
+		return
+	}
+
+	var filter dto.PlayerListQuery
+	if !bindQuery(c, &filter) {
 		return
 	}
 
-	page, perPage := getPagination(c)
+	p := getListPagination(c)
 
-	players, total, err := h.playerService.FindByTeam(uint(teamID), page, perPage)
+	players, err := h.playerService.FindByTeam(c.Request.Context(), teamID, filter, p)
 	if err != nil {
-		if err.Error() == "team not found" {
-			util.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		util.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	util.PaginatedSuccessResponse(c, http.StatusOK, "Players retrieved successfully", players, util.Meta{
-		Page:       page,
-		PerPage:    perPage,
-		Total:      total,
-		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
-	})
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Players retrieved successfully", players.Items, listMeta(p, players.Total, players.Next, players.Prev))
 }
 
 func (h *PlayerHandler) FindByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid player ID")
+	id, ok := paramID(c, "player")
+	if !ok {
 		return
 	}
 
-	player, err := h.playerService.FindByID(uint(id))
+	player, err := h.playerService.FindByID(c.Request.Context(), id)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -89,21 +75,19 @@ func (h *PlayerHandler) FindByID(c *gin.Context) {
 }
 
 func (h *PlayerHandler) Update(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid player ID")
+	id, ok := paramID(c, "player")
+	if !ok {
 		return
 	}
 
 	var req dto.UpdatePlayerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	player, err := h.playerService.Update(uint(id), req)
+	player, err := h.playerService.Update(c.Request.Context(), id, req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -111,14 +95,13 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 }
 
 func (h *PlayerHandler) Delete(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid player ID")
+	id, ok := paramID(c, "player")
+	if !ok {
 		return
 	}
 
-	if err := h.playerService.Delete(uint(id)); err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+	if err := h.playerService.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -1,9 +1,11 @@
 package handler
 
 import (
-	"math"
+	"bytes"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pranotoism/football-go/service"
@@ -12,22 +14,47 @@ import (
 
 type ReportHandler struct {
 	reportService *service.ReportService
+	sheetService  *service.MatchSheetService
 }
 
-func NewReportHandler(reportService *service.ReportService) *ReportHandler {
-	return &ReportHandler{reportService: reportService}
+func NewReportHandler(reportService *service.ReportService, sheetService *service.MatchSheetService) *ReportHandler {
+	return &ReportHandler{reportService: reportService, sheetService: sheetService}
 }
 
+var sheetTypes = map[string]string{
+	service.SheetHTML: "text/html; charset=utf-8",
+	service.SheetPDF:  "application/pdf",
+}
+
+// GetMatchReport serves the report as JSON, or as a printable sheet in the
+// format chosen by the format parameter or else the Accept header.
 func (h *ReportHandler) GetMatchReport(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid match ID")
+	id, ok := paramID(c, "match")
+	if !ok {
+		return
+	}
+
+	format, ok := reportFormat(c.Query("format"), c.GetHeader("Accept"))
+	if !ok {
+		c.Error(service.ErrInvalidReportFormat)
+		return
+	}
+	if format != "json" {
+		// The sheet is rendered in full first, so that errors still get a
+		// problem response.
+		var buf bytes.Buffer
+		if err := h.sheetService.Render(c.Request.Context(), id, format, &buf); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Header("Content-Disposition", `inline; filename="match-`+strconv.FormatUint(uint64(id), 10)+"."+format+`"`)
+		c.Data(http.StatusOK, sheetTypes[format], buf.Bytes())
 		return
 	}
 
-	report, err := h.reportService.GetMatchReport(uint(id))
+	report, err := h.reportService.GetMatchReport(c.Request.Context(), id)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -35,18 +62,37 @@ func (h *ReportHandler) GetMatchReport(c *gin.Context) {
 }
 
 func (h *ReportHandler) GetAllMatchReports(c *gin.Context) {
-	page, perPage := getPagination(c)
+	p := getListPagination(c)
 
-	reports, total, err := h.reportService.GetAllMatchReports(page, perPage)
+	reports, err := h.reportService.GetAllMatchReports(c.Request.Context(), p)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	util.PaginatedSuccessResponse(c, http.StatusOK, "Match reports retrieved successfully", reports, util.Meta{
-		Page:       page,
-		PerPage:    perPage,
-		Total:      total,
-		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
-	})
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Match reports retrieved successfully", reports.Items, listMeta(p, reports.Total, reports.Next, reports.Prev))
+}
+
+// reportFormat picks json, html or pdf. Accept only selects a sheet when
+// it prefers one to JSON, so clients asking for anything get JSON.
+func reportFormat(param, accept string) (string, bool) {
+	if param != "" {
+		_, ok := sheetTypes[param]
+		return param, ok || param == "json"
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		switch mediaType {
+		case "text/html":
+			return service.SheetHTML, true
+		case "application/pdf":
+			return service.SheetPDF, true
+		case "application/json", "*/*":
+			return "json", true
+		}
+	}
+	return "json", true
 }
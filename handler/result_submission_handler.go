@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type ResultSubmissionHandler struct {
+	submissionService *service.ResultSubmissionService
+}
+
+func NewResultSubmissionHandler(submissionService *service.ResultSubmissionService) *ResultSubmissionHandler {
+	return &ResultSubmissionHandler{submissionService: submissionService}
+}
+
+func (h *ResultSubmissionHandler) Create(c *gin.Context) {
+	matchID, ok := paramID(c, "match")
+	if !ok {
+		return
+	}
+
+	var req dto.ReportResultRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	submission, err := h.submissionService.Create(c.Request.Context(), matchID, c.GetUint("userID"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	message := "Match result submitted for approval"
+	if submission.Status == model.SubmissionDraft {
+		message = "Match result saved as draft"
+	}
+	util.SuccessResponse(c, http.StatusCreated, message, submission)
+}
+
+func (h *ResultSubmissionHandler) FindByMatch(c *gin.Context) {
+	matchID, ok := paramID(c, "match")
+	if !ok {
+		return
+	}
+
+	submissions, err := h.submissionService.FindByMatch(c.Request.Context(), matchID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Result submissions retrieved successfully", submissions)
+}
+
+func (h *ResultSubmissionHandler) FindByStatus(c *gin.Context) {
+	status := c.DefaultQuery("status", model.SubmissionSubmitted)
+	switch status {
+	case model.SubmissionDraft, model.SubmissionSubmitted, model.SubmissionApproved, model.SubmissionRejected:
+	default:
+		c.Error(service.ValidationError("invalid_status", "invalid submission status", service.FieldError{
+			Field:   "status",
+			Code:    "oneof",
+			Message: "must be one of: draft, submitted, approved, rejected",
+		}))
+		return
+	}
+
+	page, perPage := getPagination(c)
+
+	submissions, total, err := h.submissionService.FindByStatus(c.Request.Context(), status, page, perPage)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Result submissions retrieved successfully", submissions, util.PageMeta(page, perPage, total))
+}
+
+func (h *ResultSubmissionHandler) FindByID(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	submission, err := h.submissionService.FindByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Result submission retrieved successfully", submission)
+}
+
+func (h *ResultSubmissionHandler) Update(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSubmissionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	submission, err := h.submissionService.Update(c.Request.Context(), id, c.GetUint("userID"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Result submission updated successfully", submission)
+}
+
+func (h *ResultSubmissionHandler) Submit(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	submission, err := h.submissionService.Submit(c.Request.Context(), id, c.GetUint("userID"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Match result submitted for approval", submission)
+}
+
+func (h *ResultSubmissionHandler) Approve(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewSubmissionRequest
+	// The body is optional when approving
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	submission, err := h.submissionService.Approve(c.Request.Context(), id, c.GetUint("userID"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Match result approved and published", submission)
+}
+
+func (h *ResultSubmissionHandler) Reject(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewSubmissionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	submission, err := h.submissionService.Reject(c.Request.Context(), id, c.GetUint("userID"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Match result rejected", submission)
+}
+
+func (h *ResultSubmissionHandler) Diff(c *gin.Context) {
+	id, ok := paramID(c, "submission")
+	if !ok {
+		return
+	}
+
+	diff, err := h.submissionService.Diff(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Result submission diff retrieved successfully", diff)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	var query dto.SearchQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	results, err := h.searchService.Search(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Search results retrieved successfully", results)
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"math"
 	"net/http"
 	"strconv"
 
@@ -21,14 +20,13 @@ func NewTeamHandler(teamService *service.TeamService) *TeamHandler {
 
 func (h *TeamHandler) Create(c *gin.Context) {
 	var req dto.CreateTeamRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	team, err := h.teamService.Create(req)
+	team, err := h.teamService.Create(c.Request.Context(), req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -36,32 +34,31 @@ func (h *TeamHandler) Create(c *gin.Context) {
 }
 
 func (h *TeamHandler) FindAll(c *gin.Context) {
-	page, perPage := getPagination(c)
+	var filter dto.TeamListQuery
+	if !bindQuery(c, &filter) {
+		return
+	}
+
+	p := getListPagination(c)
 
-	teams, total, err := h.teamService.FindAll(page, perPage)
+	teams, err := h.teamService.FindAll(c.Request.Context(), filter, p)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	util.PaginatedSuccessResponse(c, http.StatusOK, "Teams retrieved successfully", teams, util.Meta{
-		Page:       page,
-		PerPage:    perPage,
-		Total:      total,
-		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
-	})
+	util.PaginatedSuccessResponse(c, http.StatusOK, "Teams retrieved successfully", teams.Items, listMeta(p, teams.Total, teams.Next, teams.Prev))
 }
 
 func (h *TeamHandler) FindByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid team ID")
+	id, ok := paramID(c, "team")
+	if !ok {
 		return
 	}
 
-	team, err := h.teamService.FindByID(uint(id))
+	team, err := h.teamService.FindByID(c.Request.Context(), id)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -69,21 +66,19 @@ func (h *TeamHandler) FindByID(c *gin.Context) {
 }
 
 func (h *TeamHandler) Update(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid team ID")
+	id, ok := paramID(c, "team")
+	if !ok {
 		return
 	}
 
 	var req dto.UpdateTeamRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	team, err := h.teamService.Update(uint(id), req)
+	team, err := h.teamService.Update(c.Request.Context(), id, req)
 	if err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -91,14 +86,15 @@ func (h *TeamHandler) Update(c *gin.Context) {
 }
 
 func (h *TeamHandler) Delete(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		util.ErrorResponse(c, http.StatusBadRequest, "invalid team ID")
+	id, ok := paramID(c, "team")
+	if !ok {
 		return
 	}
 
-	if err := h.teamService.Delete(uint(id)); err != nil {
-		util.ErrorResponse(c, http.StatusNotFound, err.Error())
+	cascade := c.Query("cascade") == "true"
+
+	if err := h.teamService.Delete(c.Request.Context(), id, cascade); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -118,3 +114,24 @@ func getPagination(c *gin.Context) (int, int) {
 
 	return page, perPage
 }
+
+// getListPagination reads the pagination of a list that can also be paged
+// by cursor.
+func getListPagination(c *gin.Context) dto.Pagination {
+	page, perPage := getPagination(c)
+	return dto.Pagination{
+		Page:         page,
+		PerPage:      perPage,
+		Cursor:       c.Query("cursor"),
+		IncludeTotal: c.Query("include_total") == "true",
+	}
+}
+
+func listMeta(p dto.Pagination, total *int64, next, prev string) util.Meta {
+	meta := util.Meta{PerPage: p.PerPage, Next: next, Prev: prev}
+	if p.Cursor == "" {
+		meta.Page = p.Page
+	}
+	meta.SetTotal(total)
+	return meta
+}
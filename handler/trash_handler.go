@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+func (h *TrashHandler) FindTeams(c *gin.Context) {
+	page, perPage := getPagination(c)
+	teams, total, err := h.trashService.FindTeams(c.Request.Context(), page, perPage)
+	respondTrashList(c, "Deleted teams retrieved successfully", teams, total, err, page, perPage)
+}
+
+func (h *TrashHandler) FindPlayers(c *gin.Context) {
+	page, perPage := getPagination(c)
+	players, total, err := h.trashService.FindPlayers(c.Request.Context(), page, perPage)
+	respondTrashList(c, "Deleted players retrieved successfully", players, total, err, page, perPage)
+}
+
+func (h *TrashHandler) FindMatches(c *gin.Context) {
+	page, perPage := getPagination(c)
+	matches, total, err := h.trashService.FindMatches(c.Request.Context(), page, perPage)
+	respondTrashList(c, "Deleted matches retrieved successfully", matches, total, err, page, perPage)
+}
+
+func (h *TrashHandler) FindGoals(c *gin.Context) {
+	page, perPage := getPagination(c)
+	goals, total, err := h.trashService.FindGoals(c.Request.Context(), page, perPage)
+	respondTrashList(c, "Deleted goals retrieved successfully", goals, total, err, page, perPage)
+}
+
+func (h *TrashHandler) RestoreTeam(c *gin.Context) {
+	h.handle(c, "team", "Team restored successfully", h.trashService.RestoreTeam)
+}
+
+func (h *TrashHandler) RestorePlayer(c *gin.Context) {
+	h.handle(c, "player", "Player restored successfully", h.trashService.RestorePlayer)
+}
+
+func (h *TrashHandler) RestoreMatch(c *gin.Context) {
+	h.handle(c, "match", "Match restored successfully", h.trashService.RestoreMatch)
+}
+
+func (h *TrashHandler) PurgeTeam(c *gin.Context) {
+	h.handle(c, "team", "Team permanently deleted", h.trashService.PurgeTeam)
+}
+
+func (h *TrashHandler) PurgePlayer(c *gin.Context) {
+	h.handle(c, "player", "Player permanently deleted", h.trashService.PurgePlayer)
+}
+
+func (h *TrashHandler) PurgeMatch(c *gin.Context) {
+	h.handle(c, "match", "Match permanently deleted", h.trashService.PurgeMatch)
+}
+
+func (h *TrashHandler) handle(c *gin.Context, entity, message string, action func(ctx context.Context, id uint) error) {
+	id, ok := paramID(c, entity)
+	if !ok {
+		return
+	}
+
+	if err := action(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, message, nil)
+}
+
+func respondTrashList(c *gin.Context, message string, data interface{}, total int64, err error, page, perPage int) {
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.PaginatedSuccessResponse(c, http.StatusOK, message, data, util.PageMeta(page, perPage, total))
+}
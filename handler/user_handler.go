@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+type UserHandler struct {
+	userService *service.UserService
+}
+
+func NewUserHandler(userService *service.UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	user, err := h.userService.GetProfile(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Profile retrieved successfully", toProfileResponse(user))
+}
+
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), c.GetUint("userID"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	message := "Profile updated successfully"
+	if user.PendingEmail != "" && req.Email == user.PendingEmail {
+		message = "Profile updated, check your new email address to confirm the change"
+	}
+
+	util.SuccessResponse(c, http.StatusOK, message, toProfileResponse(user))
+}
+
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	if err := h.userService.DeleteAccount(c.Request.Context(), c.GetUint("userID")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Account deleted successfully", nil)
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.VerifyEmail(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, "Email verified successfully", toProfileResponse(user))
+}
+
+func toProfileResponse(user *model.User) dto.ProfileResponse {
+	return dto.ProfileResponse{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		PendingEmail: user.PendingEmail,
+		Role:         user.Role,
+		CreatedAt:    user.CreatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+// Package ical writes calendars in the iCalendar format of RFC 5545, for
+// calendar apps to subscribe to.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const ContentType = "text/calendar; charset=utf-8"
+
+// Calendar is a feed of events. TimeZone is the IANA name of the zone that
+// clients should show the events in by default; event times themselves are
+// written in UTC.
+type Calendar struct {
+	Name     string
+	TimeZone string
+	Events   []Event
+}
+
+// Event is a calendar entry. UID must stay the same for the life of the
+// entry, so that clients update it when it changes instead of adding a new
+// one, and Sequence must grow with every change, since some clients ignore
+// updates that do not raise it. Events with AllDay set only use the date of
+// Start.
+type Event struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Modified    time.Time
+	Summary     string
+	Location    string
+	Description string
+}
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+	// maxLine is the length in octets after which lines are folded.
+	maxLine = 75
+)
+
+// Write writes c to w.
+func (c *Calendar) Write(w io.Writer) error {
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+	lw.line("BEGIN", "VCALENDAR")
+	lw.line("VERSION", "2.0")
+	lw.line("PRODID", "-//football-go//Fixtures//EN")
+	lw.line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.TimeZone != "" {
+		lw.line("X-WR-TIMEZONE", c.TimeZone)
+	}
+	for _, e := range c.Events {
+		lw.line("BEGIN", "VEVENT")
+		lw.line("UID", e.UID)
+		lw.line("SEQUENCE", strconv.Itoa(e.Sequence))
+		lw.line("DTSTAMP", e.Modified.UTC().Format(dateTimeFormat))
+		lw.line("LAST-MODIFIED", e.Modified.UTC().Format(dateTimeFormat))
+		if e.AllDay {
+			lw.line("DTSTART;VALUE=DATE", e.Start.Format(dateFormat))
+			lw.line("DTEND;VALUE=DATE", e.Start.AddDate(0, 0, 1).Format(dateFormat))
+		} else {
+			lw.line("DTSTART", e.Start.UTC().Format(dateTimeFormat))
+			lw.line("DTEND", e.End.UTC().Format(dateTimeFormat))
+		}
+		lw.line("SUMMARY", escape(e.Summary))
+		if e.Location != "" {
+			lw.line("LOCATION", escape(e.Location))
+		}
+		if e.Description != "" {
+			lw.line("DESCRIPTION", escape(e.Description))
+		}
+		lw.line("END", "VEVENT")
+	}
+	lw.line("END", "VCALENDAR")
+	if lw.err != nil {
+		return lw.err
+	}
+	return lw.w.Flush()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape escapes text property values.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// lineWriter writes content lines ending in CRLF, folding them so that no
+// line is longer than maxLine octets, without splitting UTF-8 sequences.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(name, value string) {
+	if lw.err != nil {
+		return
+	}
+	s := name + ":" + value
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		lw.w.WriteString(s[:cut])
+		lw.w.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward
+		// their length.
+		limit = maxLine - 1
+	}
+	lw.w.WriteString(s)
+	_, lw.err = lw.w.WriteString("\r\n")
+}
@@ -0,0 +1,93 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWrite(t *testing.T) {
+	modified := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	wib := time.FixedZone("WIB", 7*3600)
+	cal := &Calendar{Name: "Persib fixtures", TimeZone: "Asia/Jakarta", Events: []Event{
+		{
+			UID:         "match-1@football-go",
+			Sequence:    3,
+			Start:       time.Date(2026, 5, 10, 19, 0, 0, 0, wib),
+			End:         time.Date(2026, 5, 10, 21, 0, 0, 0, wib),
+			Modified:    modified,
+			Summary:     "Persib 2-1 Persija",
+			Location:    "Jl. Sulanjana No. 17, Bandung",
+			Description: "Opponent: Persija (home)\nFinal score: Persib 2-1 Persija",
+		},
+		{UID: "match-2@football-go", Start: time.Date(2026, 5, 17, 0, 0, 0, 0, wib), AllDay: true, Modified: modified, Summary: "Bali United vs Persib"},
+	}}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//football-go//Fixtures//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Persib fixtures",
+		"X-WR-TIMEZONE:Asia/Jakarta",
+		"BEGIN:VEVENT",
+		"UID:match-1@football-go",
+		"SEQUENCE:3",
+		"DTSTAMP:20260501T080000Z",
+		"LAST-MODIFIED:20260501T080000Z",
+		"DTSTART:20260510T120000Z",
+		"DTEND:20260510T140000Z",
+		"SUMMARY:Persib 2-1 Persija",
+		`LOCATION:Jl. Sulanjana No. 17\, Bandung`,
+		`DESCRIPTION:Opponent: Persija (home)\nFinal score: Persib 2-1 Persija`,
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:match-2@football-go",
+		"SEQUENCE:0",
+		"DTSTAMP:20260501T080000Z",
+		"LAST-MODIFIED:20260501T080000Z",
+		"DTSTART;VALUE=DATE:20260517",
+		"DTEND;VALUE=DATE:20260518",
+		"SUMMARY:Bali United vs Persib",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got := buf.String(); got != want {
+		t.Errorf("calendar =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLongLinesAreFolded(t *testing.T) {
+	summary := strings.Repeat("Pertandingan persahabatan ", 6) + "José"
+	cal := &Calendar{Events: []Event{{UID: "match-1@football-go", Summary: summary}}}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var unfolded string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a character: %q", line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded += line[1:]
+		} else if strings.HasPrefix(line, "SUMMARY:") {
+			unfolded = line
+		}
+	}
+	if unfolded != "SUMMARY:"+summary {
+		t.Errorf("unfolded summary = %q, want %q", unfolded, summary)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pranotoism/football-go/config"
+	"github.com/pranotoism/football-go/database"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
+)
+
+const importUsage = "usage: football-go import [--dry-run] [--format csv|json] teams|players|fixtures FILE"
+
+// runImport implements the import subcommand. FILE may be "-" to read from
+// stdin, in which case --format is required.
+func runImport(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, importUsage) }
+	dryRun := fs.Bool("dry-run", false, "check the rows without importing them")
+	format := fs.String("format", "", "csv or json, by default taken from the file extension")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		log.Fatal(importUsage)
+	}
+	kind, path := fs.Arg(0), fs.Arg(1)
+
+	if path == "-" && *format == "" {
+		log.Fatal("--format is required when reading from stdin")
+	}
+
+	clean, err := importFile(cfg, kind, path, *format, *dryRun)
+	if err != nil {
+		log.Fatal("Import failed: ", err)
+	}
+	if !clean {
+		os.Exit(1)
+	}
+}
+
+// importFile imports the rows of the file at path and reports whether a dry
+// run found them all valid. It returns rather than exits on failure, so
+// that the file is always closed.
+func importFile(cfg *config.Config, kind, path, format string, dryRun bool) (bool, error) {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return false, fmt.Errorf("open import file: %w", err)
+		}
+		defer f.Close()
+		in = f
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		}
+	}
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		return false, err
+	}
+	loc, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		return false, fmt.Errorf("load time zone: %w", err)
+	}
+	transactor := repository.NewTransactor(db)
+	teamRepo := repository.NewTeamRepository(db)
+	playerRepo := repository.NewPlayerRepository(db)
+	matchRepo := repository.NewMatchRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	revisionRepo := repository.NewMatchRevisionRepository(db)
+	importService := service.NewImportService(transactor,
+		service.NewTeamService(transactor, teamRepo, playerRepo, matchRepo, goalRepo),
+		service.NewPlayerService(playerRepo, teamRepo, goalRepo),
+		service.NewMatchService(transactor, matchRepo, teamRepo, goalRepo, revisionRepo, loc),
+	)
+
+	result, err := importService.Import(context.Background(), kind, format, in, dryRun)
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		printRowErrors(serviceErr.Fields)
+		return false, errors.New(serviceErr.Message)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if dryRun {
+		printRowErrors(result.Errors)
+		fmt.Printf("Checked %d %s rows, %d errors, nothing was imported\n", result.Rows, kind, len(result.Errors))
+		return len(result.Errors) == 0, nil
+	}
+	fmt.Printf("Imported %d %s\n", result.Imported, kind)
+	return true, nil
+}
+
+func printRowErrors(errs []service.FieldError) {
+	for _, fe := range errs {
+		fmt.Fprintf(os.Stderr, "%s: %s (%s)\n", fe.Field, fe.Message, fe.Code)
+	}
+}
@@ -0,0 +1,64 @@
+// Package logging configures the application's structured JSON logger.
+// Records logged with a context carry the request ID and the authenticated
+// user ID found in it.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/pranotoism/football-go/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New returns a JSON logger writing records at level and above to w.
+func New(w io.Writer, level string) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: ParseLevel(level)})
+	return slog.New(contextHandler{handler})
+}
+
+// ParseLevel converts a configured level name to a slog.Level, defaulting
+// to info.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// contextHandler adds request_id, user_id and the trace and span IDs to
+// records logged with a request context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if requestID := util.RequestIDFromContext(ctx); requestID != "" {
+			r.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID, ok := util.ActorIDFromContext(ctx); ok {
+			r.AddAttrs(slog.Uint64("user_id", uint64(userID)))
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/pranotoism/football-go/util"
+)
+
+func TestLoggerAddsRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "info")
+
+	ctx := util.WithActorID(util.WithRequestID(context.Background(), "req-1"), 42)
+	logger.With("component", "test").InfoContext(ctx, "hello")
+	logger.DebugContext(ctx, "hidden")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "hello" || record["request_id"] != "req-1" || record["user_id"] != float64(42) || record["component"] != "test" {
+		t.Errorf("record = %v", record)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for level, want := range map[string]slog.Level{"debug": slog.LevelDebug, "warn": slog.LevelWarn, "error": slog.LevelError, "": slog.LevelInfo} {
+		if got := ParseLevel(level); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", level, got, want)
+		}
+	}
+}
@@ -1,64 +1,194 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/pranotoism/football-go/config"
 	"github.com/pranotoism/football-go/database"
 	"github.com/pranotoism/football-go/handler"
-	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/logging"
+	"github.com/pranotoism/football-go/matchsheet"
+	"github.com/pranotoism/football-go/metrics"
+	"github.com/pranotoism/football-go/middleware"
 	"github.com/pranotoism/football-go/repository"
 	"github.com/pranotoism/football-go/router"
+	"github.com/pranotoism/football-go/server"
 	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/tracing"
 	"github.com/pranotoism/football-go/util"
 )
 
 func main() {
 	// Load config
-	cfg := config.Load()
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("Failed to load config: ", err)
+	}
+
+	if len(args) > 0 && args[0] == "config" {
+		runConfig(cfg)
+		return
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid config:\n%v", err)
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			runMigrate(cfg, args[1:])
+		case "import":
+			runImport(cfg, args[1:])
+		case "export":
+			runExport(cfg, args[1:])
+		default:
+			log.Fatalf("unknown command %q, expected config, migrate, import or export", args[0])
+		}
+		return
+	}
 
-	// Set JWT secret
-	util.SetJWTSecret(cfg.JWTSecret)
+	// Log JSON to stdout; the standard log package is routed through it too
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log.Level))
+	gin.SetMode(gin.ReleaseMode)
+
+	// Set up tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+
+	// Set JWT signing keys
+	if cfg.Auth.JWTKeys != "" {
+		keys, err := util.LoadSigningKeys(cfg.Auth.JWTKeys)
+		if err != nil {
+			fatal("failed to load JWT signing keys", err)
+		}
+		util.SetSigningKeys(keys)
+	} else {
+		util.SetJWTSecret(cfg.Auth.JWTSecret)
+	}
 
 	// Connect database
-	db := database.Connect(cfg)
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	// Matches are scheduled in, and dates filtered by, the time zone of the
+	// database unless a match has its own
+	loc, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		fatal("failed to load time zone", err)
+	}
 
-	// Auto migrate
-	database.Migrate(db,
-		&model.User{},
-		&model.Team{},
-		&model.Player{},
-		&model.Match{},
-		&model.Goal{},
-	)
+	// Apply pending migrations
+	migrator, err := database.Migrate(db, loc)
+	if err != nil {
+		fatal("failed to migrate database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("failed to access database pool", err)
+	}
+	if err := metrics.RegisterDBStats(sqlDB, cfg.Database.Name); err != nil {
+		fatal("failed to register database metrics", err)
+	}
+
+	// Match report templates, with the overrides of the templates directory
+	sheets, err := matchsheet.New(cfg.Report.TemplatesDir, matchsheet.CrestClient())
+	if err != nil {
+		fatal("failed to load report templates", err)
+	}
+
+	// Outgoing mail
+	mailer, err := newMailer(cfg.Mail)
+	if err != nil {
+		fatal("failed to set up mailer", err)
+	}
 
 	// Repositories
+	transactor := repository.NewTransactor(db)
 	userRepo := repository.NewUserRepository(db)
 	teamRepo := repository.NewTeamRepository(db)
 	playerRepo := repository.NewPlayerRepository(db)
 	matchRepo := repository.NewMatchRepository(db)
 	goalRepo := repository.NewGoalRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	revisionRepo := repository.NewMatchRevisionRepository(db)
+	submissionRepo := repository.NewResultSubmissionRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
 
 	// Services
 	authService := service.NewAuthService(userRepo)
-	teamService := service.NewTeamService(teamRepo, playerRepo)
-	playerService := service.NewPlayerService(playerRepo, teamRepo)
-	matchService := service.NewMatchService(matchRepo, teamRepo, goalRepo)
+	userService := service.NewUserService(transactor, userRepo, mailer)
+	teamService := service.NewTeamService(transactor, teamRepo, playerRepo, matchRepo, goalRepo)
+	playerService := service.NewPlayerService(playerRepo, teamRepo, goalRepo)
+	matchService := service.NewMatchService(transactor, matchRepo, teamRepo, goalRepo, revisionRepo, loc)
+	submissionService := service.NewResultSubmissionService(submissionRepo, matchService)
 	reportService := service.NewReportService(matchRepo)
+	trashService := service.NewTrashService(transactor, teamRepo, playerRepo, matchRepo, goalRepo, time.Duration(cfg.Trash.RetentionDays)*24*time.Hour)
+	auditService := service.NewAuditService(auditRepo)
+	searchService := service.NewSearchService(searchRepo)
+	importService := service.NewImportService(transactor, teamService, playerService, matchService)
+	exportService := service.NewExportService(teamRepo, playerRepo, matchRepo, goalRepo, reportService, loc)
+	calendarService := service.NewCalendarService(matchRepo, teamRepo, loc)
+	matchSheetService := service.NewMatchSheetService(reportService, playerRepo, sheets)
+	healthService := service.NewHealthService(migrator)
+	healthService.AddCheck("database", sqlDB.PingContext)
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
+	userHandler := handler.NewUserHandler(userService)
 	teamHandler := handler.NewTeamHandler(teamService)
 	playerHandler := handler.NewPlayerHandler(playerService)
 	matchHandler := handler.NewMatchHandler(matchService)
-	reportHandler := handler.NewReportHandler(reportService)
+	reportHandler := handler.NewReportHandler(reportService, matchSheetService)
+	jwksHandler := handler.NewJWKSHandler()
+	auditHandler := handler.NewAuditHandler(auditService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	importHandler := handler.NewImportHandler(importService, int64(cfg.Import.MaxBytes))
+	exportHandler := handler.NewExportHandler(exportService, cfg.Server.WriteTimeout.Duration)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	submissionHandler := handler.NewResultSubmissionHandler(submissionService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	healthHandler := handler.NewHealthHandler(healthService)
 
 	// Setup router
-	r := router.Setup(authHandler, teamHandler, playerHandler, matchHandler, reportHandler)
+	r := router.Setup(cfg, middleware.AuthMiddleware(authService), authHandler, userHandler, teamHandler, playerHandler, matchHandler, reportHandler, jwksHandler, auditHandler, submissionHandler, trashHandler, searchHandler, importHandler, exportHandler, calendarHandler, healthHandler)
 
-	// Start server
-	log.Printf("Server starting on port %s", cfg.AppPort)
-	if err := r.Run(":" + cfg.AppPort); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Start server and shut down on SIGINT or SIGTERM
+	srv := server.New(cfg.Server, r)
+	srv.OnDrain(healthService.Drain)
+	srv.OnShutdown("database", func(ctx context.Context) error {
+		return sqlDB.Close()
+	})
+	srv.OnShutdown("tracing", shutdownTracing)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := srv.Run(ctx); err != nil {
+		fatal("server stopped with errors", err)
 	}
+	slog.Info("server stopped")
+}
+
+func newMailer(cfg config.MailConfig) (util.Mailer, error) {
+	if cfg.Driver == "smtp" {
+		return util.NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From)
+	}
+	return util.NewLogMailer(), nil
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,81 @@
+package matchsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// maxCrestBytes bounds the size of a crest image.
+const maxCrestBytes = 2 << 20
+
+// imageTypes maps the content types of crests to fpdf image types.
+var imageTypes = map[string]string{
+	"image/png":  "PNG",
+	"image/jpeg": "JPG",
+	"image/gif":  "GIF",
+}
+
+var errPrivateAddress = errors.New("crest address is not public")
+
+// CrestClient returns the HTTP client to fetch crests with. Since crest
+// URLs come from users, it refuses to connect to loopback, private and
+// link-local addresses, so that a crest cannot be used to probe the
+// network the server runs in.
+func CrestClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+				return fmt.Errorf("%w: %s", errPrivateAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport, Timeout: 10 * time.Second}
+}
+
+// fetchCrest downloads the crest at rawURL, reporting false when there is
+// none or it is not a PNG, JPEG or GIF image.
+func (r *Renderer) fetchCrest(ctx context.Context, rawURL string) ([]byte, string, bool) {
+	if rawURL == "" || r.client == nil {
+		return nil, "", false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, "", false
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", false
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, "", false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCrestBytes+1))
+	if err != nil || len(data) > maxCrestBytes {
+		return nil, "", false
+	}
+	imageType, ok := imageTypes[http.DetectContentType(data)]
+	return data, imageType, ok
+}
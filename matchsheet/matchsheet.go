@@ -0,0 +1,156 @@
+// Package matchsheet renders match reports as printable HTML and PDF
+// sheets from templates.
+//
+// Each sheet has two templates: match.html, an html/template, and
+// match.pdf.tmpl, a text/template whose output lays out the PDF one line
+// at a time (see PDF). The built-in templates can be overridden by files of
+// the same name in a templates directory, for all competitions at its top
+// level or for one competition in a subdirectory named after its Slug, e.g.
+// "liga-1/match.html". A file missing from a directory falls back to the
+// one a level up.
+package matchsheet
+
+import (
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode"
+
+	"github.com/pranotoism/football-go/dto"
+)
+
+const (
+	htmlTemplate = "match.html"
+	pdfTemplate  = "match.pdf.tmpl"
+)
+
+//go:embed templates
+var builtin embed.FS
+
+// Sheet is the data templates are executed with: the match report along
+// with the current squads of both teams, which the report itself lacks.
+// The scores of a match that has not been Played are zero and should not
+// be shown.
+type Sheet struct {
+	dto.MatchReport
+	Played      bool
+	HomeSquad   []SquadPlayer
+	AwaySquad   []SquadPlayer
+	GeneratedAt time.Time
+}
+
+type SquadPlayer struct {
+	JerseyNumber int
+	Name         string
+	Position     string
+}
+
+type templates struct {
+	html *htmltemplate.Template
+	pdf  *texttemplate.Template
+}
+
+// Renderer renders sheets with the templates of their competition.
+type Renderer struct {
+	sets   map[string]templates
+	client *http.Client
+}
+
+// New loads the built-in templates and the overrides in dir, if any.
+// Crests are fetched for PDFs with client; HTML sheets link to them
+// instead.
+func New(dir string, client *http.Client) (*Renderer, error) {
+	sub, err := fs.Sub(builtin, "templates")
+	if err != nil {
+		return nil, err
+	}
+	base, err := parse(sub, templates{})
+	if err != nil {
+		return nil, err
+	}
+	r := &Renderer{sets: map[string]templates{"": base}, client: client}
+	if dir == "" {
+		return r, nil
+	}
+
+	root := os.DirFS(dir)
+	if r.sets[""], err = parse(root, base); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		set, err := parse(os.DirFS(filepath.Join(dir, e.Name())), r.sets[""])
+		if err != nil {
+			return nil, err
+		}
+		r.sets[e.Name()] = set
+	}
+	return r, nil
+}
+
+// parse reads the templates in fsys, keeping those of fallback for files
+// it does not have.
+func parse(fsys fs.FS, fallback templates) (templates, error) {
+	set := fallback
+	if data, err := fs.ReadFile(fsys, htmlTemplate); err == nil {
+		if set.html, err = htmltemplate.New(htmlTemplate).Parse(string(data)); err != nil {
+			return templates{}, err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return templates{}, err
+	}
+	if data, err := fs.ReadFile(fsys, pdfTemplate); err == nil {
+		if set.pdf, err = texttemplate.New(pdfTemplate).Parse(string(data)); err != nil {
+			return templates{}, err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return templates{}, err
+	}
+	return set, nil
+}
+
+// Slug names the template directory of a competition: its name in lower
+// case, with runs of other characters than letters and digits turned into
+// single hyphens. "Liga 1" becomes "liga-1".
+func Slug(competition string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(competition) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+func (r *Renderer) templates(competition string) templates {
+	if set, ok := r.sets[Slug(competition)]; ok && competition != "" {
+		return set
+	}
+	return r.sets[""]
+}
+
+// HTML writes s as an HTML page.
+func (r *Renderer) HTML(w io.Writer, s *Sheet) error {
+	return r.templates(s.Competition).html.Execute(w, s)
+}
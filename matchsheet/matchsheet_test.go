@@ -0,0 +1,224 @@
+package matchsheet
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+)
+
+func sheet(competition string) *Sheet {
+	return &Sheet{
+		MatchReport: dto.MatchReport{
+			MatchID:     7,
+			MatchDate:   "2026-05-10",
+			MatchTime:   "19:00",
+			TimeZone:    "Asia/Jakarta",
+			Competition: competition,
+			HomeTeam:    dto.TeamInfo{ID: 1, Name: "Persib"},
+			AwayTeam:    dto.TeamInfo{ID: 2, Name: "Persija"},
+			HomeScore:   2,
+			AwayScore:   1,
+			Status:      "Home Win",
+			Goals:       []dto.GoalDetail{{PlayerName: "Ciro <Alves>", TeamName: "Persib", Minute: 23}},
+		},
+		Played:      true,
+		HomeSquad:   []SquadPlayer{{JerseyNumber: 9, Name: "Ciro <Alves>", Position: "penyerang"}},
+		GeneratedAt: time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHTML(t *testing.T) {
+	r, err := New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.HTML(&buf, sheet("Liga 1")); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Liga 1", "2026-05-10 19:00 (Asia/Jakarta)", "Persib", "Persija", "23'", "Ciro &lt;Alves&gt;", "Match #7"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML lacks %q", want)
+		}
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("match.html", "default {{.HomeTeam.Name}}")
+	write("liga-1/match.html", "liga {{.HomeTeam.Name}}")
+
+	r, err := New(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for competition, want := range map[string]string{"Liga 1": "liga Persib", "Piala Presiden": "default Persib", "": "default Persib"} {
+		var buf bytes.Buffer
+		if err := r.HTML(&buf, sheet(competition)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != want {
+			t.Errorf("HTML for %q = %q, want %q", competition, buf.String(), want)
+		}
+	}
+
+	// The competition has no PDF template of its own, so it gets the
+	// built-in one.
+	var buf bytes.Buffer
+	if err := r.PDF(context.Background(), &buf, sheet("Liga 1")); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Error("output is not a PDF")
+	}
+
+	write("broken/match.pdf.tmpl", "{{.Nope")
+	if _, err := New(dir, nil); err == nil {
+		t.Error("New accepted a template that does not parse")
+	}
+}
+
+func TestPDFLayoutEscapesValues(t *testing.T) {
+	r, err := New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sheet("Liga 1\r\ntitle Forged")
+	s.HomeTeam.Name = "Persib\nscore 9 - 0"
+	s.Goals[0].PlayerName = "Ciro | Alves"
+	s.HomeSquad = append(s.HomeSquad, SquadPlayer{JerseyNumber: 10, Name: "Beckham |", Position: "gelandang"})
+	s.TopScorer = &dto.TopScorer{PlayerName: "Ciro\rAlves", Goals: 1}
+
+	layout, err := r.layout(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := layout.String()
+	for _, want := range []string{
+		"subtitle Liga 1 title Forged\n",
+		"title Persib score 9 - 0 vs Persija\n",
+		"score 2 - 1\n",
+		"row 23' | Ciro / Alves | Persib\n",
+		"row 10 | Beckham / | gelandang\n",
+		"row Top scorer | Ciro Alves (1)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("layout lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "\nscore ") != 1 || strings.Contains(out, "\ntitle Forged") {
+		t.Errorf("values added directives:\n%s", out)
+	}
+	if s.HomeTeam.Name != "Persib\nscore 9 - 0" || s.Goals[0].PlayerName != "Ciro | Alves" || s.TopScorer.PlayerName != "Ciro\rAlves" {
+		t.Error("layout changed the sheet it was given")
+	}
+}
+
+func TestUnplayedMatchHasNoScore(t *testing.T) {
+	r, err := New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sheet("")
+	s.Played, s.HomeScore, s.AwayScore, s.Status = false, 0, 0, ""
+
+	layout, err := r.layout(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := layout.String(); strings.Contains(out, "score ") || !strings.Contains(out, "subtitle Not played\n") {
+		t.Errorf("PDF layout of an unplayed match:\n%s", out)
+	}
+
+	var buf bytes.Buffer
+	if err := r.HTML(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "0&ndash;0") || !strings.Contains(out, "Not played") {
+		t.Errorf("HTML of an unplayed match:\n%s", out)
+	}
+}
+
+func TestPDFCrests(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	img.Set(1, 1, color.Black)
+	var crest bytes.Buffer
+	if err := png.Encode(&crest, img); err != nil {
+		t.Fatal(err)
+	}
+	fetched := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		if r.URL.Path != "/crest.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(crest.Bytes())
+	}))
+	defer srv.Close()
+
+	r, err := New("", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sheet("")
+	s.HomeTeam.LogoURL = srv.URL + "/crest.png"
+	s.AwayTeam.LogoURL = srv.URL + "/missing.png"
+
+	var buf bytes.Buffer
+	if err := r.PDF(context.Background(), &buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if fetched != 2 {
+		t.Errorf("fetched %d crests, want 2", fetched)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("/Subtype /Image")) {
+		t.Error("PDF has no crest image")
+	}
+}
+
+func TestCrestClientRefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("crest client reached a loopback address")
+	}))
+	defer srv.Close()
+
+	r := &Renderer{client: CrestClient()}
+	if _, _, ok := r.fetchCrest(context.Background(), srv.URL); ok {
+		t.Error("fetched a crest from a loopback address")
+	}
+}
+
+func TestSlug(t *testing.T) {
+	for in, want := range map[string]string{
+		"Liga 1":               "liga-1",
+		"  Piala  Presiden! ":  "piala-presiden",
+		"AFC Champions League": "afc-champions-league",
+		"":                     "",
+	} {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
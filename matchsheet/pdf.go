@@ -0,0 +1,202 @@
+package matchsheet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pageMargin = 15.0
+	crestSize  = 28.0
+	font       = "Helvetica"
+)
+
+// PDF writes s as an A4 page. The output of the PDF template is read line
+// by line, each line being a directive and its text:
+//
+//	title TEXT       large bold centred text
+//	subtitle TEXT    small centred text
+//	score TEXT       the score, very large
+//	crests           the crests of both teams, side by side
+//	heading TEXT     a section heading
+//	subheading TEXT  a heading within a section
+//	row A | B | C    a table row, its cells of equal width
+//	text TEXT        a paragraph
+//	footer TEXT      small print
+//	space            a blank gap
+//
+// Blank lines are skipped, and lines starting with anything else are
+// printed as text. Line breaks and bars in the values of s are replaced
+// before the template sees them, so that names cannot add directives or
+// table cells.
+func (r *Renderer) PDF(ctx context.Context, w io.Writer, s *Sheet) error {
+	layout, err := r.layout(s)
+	if err != nil {
+		return err
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(s.HomeTeam.Name+" vs "+s.AwayTeam.Name, true)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pageWidth, _ := pdf.GetPageSize()
+	width := pageWidth - 2*pageMargin
+
+	scanner := bufio.NewScanner(layout)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		directive, text, _ := strings.Cut(line, " ")
+		text = tr(strings.TrimSpace(text))
+
+		pdf.SetTextColor(0, 0, 0)
+		switch directive {
+		case "title":
+			pdf.SetFont(font, "B", 18)
+			pdf.MultiCell(0, 9, text, "", "C", false)
+		case "subtitle":
+			pdf.SetFont(font, "", 11)
+			pdf.SetTextColor(90, 90, 90)
+			pdf.MultiCell(0, 6, text, "", "C", false)
+		case "score":
+			pdf.SetFont(font, "B", 30)
+			pdf.CellFormat(0, 16, text, "", 1, "C", false, 0, "")
+		case "crests":
+			r.crests(ctx, pdf, s, width)
+		case "heading":
+			pdf.Ln(4)
+			pdf.SetFont(font, "B", 13)
+			pdf.CellFormat(0, 8, text, "B", 1, "L", false, 0, "")
+			pdf.Ln(2)
+		case "subheading":
+			pdf.SetFont(font, "B", 11)
+			pdf.CellFormat(0, 7, text, "", 1, "L", false, 0, "")
+		case "row":
+			pdf.SetFont(font, "", 10)
+			cells := strings.Split(text, " | ")
+			cellWidth := width / float64(len(cells))
+			for _, cell := range cells {
+				pdf.CellFormat(cellWidth, 6, fit(pdf, strings.TrimSpace(cell), cellWidth-2), "", 0, "L", false, 0, "")
+			}
+			pdf.Ln(6)
+		case "footer":
+			pdf.SetFont(font, "", 8)
+			pdf.SetTextColor(140, 140, 140)
+			pdf.MultiCell(0, 5, text, "", "L", false)
+		case "space":
+			pdf.Ln(5)
+		default:
+			pdf.SetFont(font, "", 10)
+			if directive != "text" {
+				text = tr(line)
+			}
+			pdf.MultiCell(0, 6, text, "", "L", false)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+// layout executes the PDF template with a copy of s whose strings are fit
+// for a single line and cell.
+func (r *Renderer) layout(s *Sheet) (*bytes.Buffer, error) {
+	clean := *s
+	scrub(reflect.ValueOf(&clean).Elem())
+
+	var layout bytes.Buffer
+	if err := r.templates(s.Competition).pdf.Execute(&layout, &clean); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+var layoutText = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ", "|", "/")
+
+// scrub rewrites the exported strings in v with layoutText, copying slices
+// and pointers on the way so that the values v shares are left alone.
+func scrub(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(layoutText.Replace(v.String()))
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				scrub(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		elems := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(elems, v)
+		for i := range elems.Len() {
+			scrub(elems.Index(i))
+		}
+		v.Set(elems)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		elem := reflect.New(v.Type().Elem())
+		elem.Elem().Set(v.Elem())
+		scrub(elem.Elem())
+		v.Set(elem)
+	}
+}
+
+// crests draws the crests of both teams, under their half of the page.
+// Teams without a crest, or whose crest cannot be fetched, get none.
+func (r *Renderer) crests(ctx context.Context, pdf *fpdf.Fpdf, s *Sheet, width float64) {
+	y := pdf.GetY() + 2
+	drawn := false
+	for i, url := range []string{s.HomeTeam.LogoURL, s.AwayTeam.LogoURL} {
+		data, imageType, ok := r.fetchCrest(ctx, url)
+		if !ok {
+			continue
+		}
+		name := "crest" + string(rune('0'+i))
+		options := fpdf.ImageOptions{ImageType: imageType}
+		info := pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(data))
+		if pdf.Err() || info == nil || info.Width() <= 0 || info.Height() <= 0 {
+			pdf.ClearError()
+			continue
+		}
+		// Fit the crest in a square, keeping its aspect ratio.
+		w, h := crestSize, crestSize
+		if info.Width() > info.Height() {
+			h = crestSize * info.Height() / info.Width()
+		} else {
+			w = crestSize * info.Width() / info.Height()
+		}
+		x := pageMargin + width/4 + float64(i)*width/2 - w/2
+		pdf.ImageOptions(name, x, y+(crestSize-h)/2, w, h, false, options, 0, "")
+		drawn = true
+	}
+	if drawn {
+		pdf.SetY(y + crestSize + 2)
+	}
+}
+
+// fit shortens s with an ellipsis until it fits in width.
+func fit(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for len(s) > 0 && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	ResultInitial   = "initial"
+	ResultAmendment = "amendment"
+
+	LoginUnknownEmail  = "unknown_email"
+	LoginWrongPassword = "wrong_password"
+)
+
+var (
+	ResultsReported = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "results_reported_total",
+		Help:      "Match results published, by kind (initial or amendment).",
+	}, []string{"kind"})
+
+	GoalsRecorded = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "goals_recorded_total",
+		Help:      "Goals recorded with published match results.",
+	})
+
+	LoginFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Failed login attempts, by reason.",
+	}, []string{"reason"})
+)
+
+// Start every known series at zero, so rates work from the first event.
+func init() {
+	for _, kind := range []string{ResultInitial, ResultAmendment} {
+		ResultsReported.WithLabelValues(kind)
+	}
+	for _, reason := range []string{LoginUnknownEmail, LoginWrongPassword} {
+		LoginFailures.WithLabelValues(reason)
+	}
+}
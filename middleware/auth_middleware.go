@@ -5,10 +5,11 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/service"
 	"github.com/pranotoism/football-go/util"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -24,14 +25,16 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := util.ValidateToken(parts[1])
+		user, err := authService.Authenticate(c.Request.Context(), parts[1])
 		if err != nil {
-			util.ErrorResponse(c, http.StatusUnauthorized, "invalid or expired token")
+			c.Error(err)
 			c.Abort()
 			return
 		}
 
-		c.Set("userID", claims.UserID)
+		c.Set("userID", user.ID)
+		c.Set("userRole", user.Role)
+		c.Request = c.Request.WithContext(util.WithActorID(c.Request.Context(), user.ID))
 		c.Next()
 	}
 }
@@ -1,22 +1,75 @@
 package middleware
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
 	"github.com/pranotoism/football-go/util"
 )
 
+var kindStatus = map[service.Kind]int{
+	service.KindValidation:         http.StatusBadRequest,
+	service.KindUnauthorized:       http.StatusUnauthorized,
+	service.KindForbidden:          http.StatusForbidden,
+	service.KindNotFound:           http.StatusNotFound,
+	service.KindConflict:           http.StatusConflict,
+	service.KindPreconditionFailed: http.StatusPreconditionFailed,
+}
+
+// ErrorHandler turns panics and errors that handlers attach with c.Error
+// into problem+json responses. Service errors map to a status by kind; any
+// other error is logged and answered with a generic 500, so internal
+// details never reach the client. Errors after the response has started
+// are only logged.
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Printf("Panic recovered: %v", err)
+				slog.ErrorContext(c.Request.Context(), "panic recovered",
+					"panic", err,
+					"stack", string(debug.Stack()),
+				)
 				util.ErrorResponse(c, http.StatusInternalServerError, "internal server error")
 				c.Abort()
 			}
 		}()
 		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+		// A streamed response may fail after it has started, when only
+		// logging the error is left.
+		if c.Writer.Written() {
+			slog.ErrorContext(c.Request.Context(), "response failed after it started", "error", c.Errors.Last().Err)
+			return
+		}
+		writeError(c, c.Errors.Last().Err)
+	}
+}
+
+func writeError(c *gin.Context, err error) {
+	var serviceErr *service.Error
+	switch {
+	case errors.As(err, &serviceErr):
+		status, ok := kindStatus[serviceErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		var fields interface{}
+		if len(serviceErr.Fields) > 0 {
+			fields = serviceErr.Fields
+		}
+		util.ProblemResponse(c, status, serviceErr.Code, serviceErr.Message, fields)
+	case errors.Is(err, repository.ErrNotFound):
+		util.ErrorResponse(c, http.StatusNotFound, "resource not found")
+	default:
+		slog.ErrorContext(c.Request.Context(), "request failed", "error", err)
+		util.ErrorResponse(c, http.StatusInternalServerError, "internal server error")
 	}
 }
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+func TestErrorHandlerWritesProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"not found", service.ErrTeamNotFound, http.StatusNotFound, "team_not_found", "team not found"},
+		{"conflict", service.ErrJerseyNumberTaken, http.StatusConflict, "jersey_number_taken", "jersey number already taken in this team"},
+		{"validation", service.ErrSameTeams, http.StatusBadRequest, "same_teams", "home team and away team cannot be the same"},
+		{"forbidden", service.ErrNotReporter, http.StatusForbidden, "not_reporter", "only the reporter can change this submission"},
+		{"precondition", service.ErrResultNotReported, http.StatusPreconditionFailed, "result_not_reported", "match result has not been reported yet"},
+		{"unauthorized", service.ErrTokenRevoked, http.StatusUnauthorized, "token_revoked", "token has been revoked"},
+		{"formatted", service.ErrSubmissionState.Withf("a %s submission cannot be approved", "draft"), http.StatusPreconditionFailed, "invalid_submission_state", "a draft submission cannot be approved"},
+		{"repository not found", repository.ErrNotFound, http.StatusNotFound, "not_found", "resource not found"},
+		{"internal", errors.New("pq: connection refused"), http.StatusInternalServerError, "internal_server_error", "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler())
+			r.GET("/teams/:id", func(c *gin.Context) { c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/teams/7", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != util.ProblemContentType {
+				t.Fatalf("expected content type %q, got %q", util.ProblemContentType, got)
+			}
+
+			var problem util.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatal(err)
+			}
+			want := util.Problem{
+				Type:      "urn:football-go:problem:" + tt.wantCode,
+				Title:     http.StatusText(tt.wantStatus),
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  "/teams/7",
+				Code:      tt.wantCode,
+				RequestID: "req-1",
+			}
+			if problem != want {
+				t.Fatalf("expected %+v, got %+v", want, problem)
+			}
+		})
+	}
+}
+
+func TestErrorHandlerIncludesFieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.POST("/teams", func(c *gin.Context) {
+		c.Error(service.ValidationError("invalid_fields", "request has invalid fields", service.FieldError{
+			Field: "name", Code: "required", Message: "is required",
+		}))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/teams", nil))
+
+	var body struct {
+		Errors []service.FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := service.FieldError{Field: "name", Code: "required", Message: "is required"}
+	if len(body.Errors) != 1 || body.Errors[0] != want {
+		t.Fatalf("expected errors [%+v], got %+v", want, body.Errors)
+	}
+}
+
+func TestErrorHandlerRecoversPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != util.ProblemContentType {
+		t.Fatalf("expected content type %q, got %q", util.ProblemContentType, got)
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/metrics"
+	"github.com/pranotoism/football-go/util"
+)
+
+// Metrics records every request in the HTTP metrics. Requests that match no
+// route are labelled "unmatched" so that scanners cannot create new series.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		metrics.HTTPResponseSize.WithLabelValues(method, route).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
+
+// MetricsAuth requires "Authorization: Bearer <token>" on the metrics
+// endpoint. With an empty token the endpoint is open.
+func MetricsAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+token)) != 1 {
+			util.ErrorResponse(c, http.StatusUnauthorized, "invalid metrics token")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/config"
+	"github.com/pranotoism/football-go/util"
+	"golang.org/x/time/rate"
+)
+
+// limiterIdleTTL is how long a client's limiter is kept after its last
+// request.
+const limiterIdleTTL = 10 * time.Minute
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimit limits each client IP to a token bucket refilled at
+// cfg.RequestsPerSecond holding up to cfg.Burst requests. Requests over the
+// limit get 429 with a Retry-After header. When the limit is disabled the
+// middleware does nothing.
+func RateLimit(cfg config.RateLimitConfig) gin.HandlerFunc {
+	if !cfg.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	var (
+		mu        sync.Mutex
+		clients   = make(map[string]*clientLimiter)
+		lastSweep = time.Now()
+	)
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		if now.Sub(lastSweep) > limiterIdleTTL {
+			for key, client := range clients {
+				if now.Sub(client.lastSeen) > limiterIdleTTL {
+					delete(clients, key)
+				}
+			}
+			lastSweep = now
+		}
+		client, ok := clients[ip]
+		if !ok {
+			client = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst)}
+			clients[ip] = client
+		}
+		client.lastSeen = now
+		reservation := client.limiter.ReserveN(now, 1)
+		mu.Unlock()
+
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			util.ErrorResponse(c, http.StatusTooManyRequests, "too many requests")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/util"
+)
+
+// RequireRole only lets the request through when the authenticated user has
+// one of the given roles. It must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("userRole")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		util.ErrorResponse(c, http.StatusForbidden, "you do not have permission to perform this action")
+		c.Abort()
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "net/http"
+
+// untracedPaths are polled by orchestrators and scrapers; tracing them
+// would only add noise.
+var untracedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// TraceFilter reports whether a request should be traced.
+func TraceFilter(r *http.Request) bool {
+	return !untracedPaths[r.URL.Path]
+}
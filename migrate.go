@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pranotoism/football-go/config"
+	"github.com/pranotoism/football-go/database"
+)
+
+const migrateUsage = "usage: football-go migrate up|down|status|to VERSION"
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database: ", err)
+	}
+	loc, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		log.Fatal("Failed to load time zone: ", err)
+	}
+	migrator, err := database.NewMigrator(db, loc)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			log.Fatal(migrateUsage)
+		}
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			log.Fatalf("invalid version %q", args[1])
+		}
+		err = migrator.To(ctx, version)
+	case "status":
+		var statuses []database.MigrationStatus
+		statuses, err = migrator.Status(ctx)
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(os.Stdout, "%04d  %-30s %s\n", s.Version, s.Name, applied)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+
+	if err != nil {
+		log.Fatal("Migration failed:", err)
+	}
+}
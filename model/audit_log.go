@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type AuditLog struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Entity    string    `json:"entity" gorm:"size:64;not null;index"`
+	EntityID  uint      `json:"entity_id" gorm:"index"`
+	Action    string    `json:"action" gorm:"size:16;not null"`
+	ActorID   *uint     `json:"actor_id" gorm:"index"`
+	RequestID string    `json:"request_id" gorm:"size:128"`
+	Before    string    `json:"before,omitempty" gorm:"type:text"`
+	After     string    `json:"after,omitempty" gorm:"type:text"`
+	Changes   string    `json:"changes,omitempty" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
@@ -12,9 +12,9 @@ type Goal struct {
 	PlayerID  uint           `json:"player_id" gorm:"not null"`
 	TeamID    uint           `json:"team_id" gorm:"not null"`
 	Minute    int            `json:"minute" gorm:"not null"`
-	Player    *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
-	Team      *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
-	Match     *Match         `json:"match,omitempty" gorm:"foreignKey:MatchID"`
+	Player    *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Team      *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Match     *Match         `json:"match,omitempty" gorm:"foreignKey:MatchID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
@@ -1,23 +1,82 @@
 package model
 
 import (
+	"encoding/json"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Match is a fixture. KickoffAt is stored in UTC; TimeZone is the IANA time
+// zone of the venue, in which the match date and time are shown.
 type Match struct {
-	ID         uint           `json:"id" gorm:"primaryKey"`
-	MatchDate  string         `json:"match_date" gorm:"size:10;not null"`
-	MatchTime  string         `json:"match_time" gorm:"size:8;not null"`
-	HomeTeamID uint           `json:"home_team_id" gorm:"not null"`
-	AwayTeamID uint           `json:"away_team_id" gorm:"not null"`
-	HomeScore  *int           `json:"home_score"`
-	AwayScore  *int           `json:"away_score"`
-	HomeTeam   *Team          `json:"home_team,omitempty" gorm:"foreignKey:HomeTeamID"`
-	AwayTeam   *Team          `json:"away_team,omitempty" gorm:"foreignKey:AwayTeamID"`
-	Goals      []Goal         `json:"goals,omitempty" gorm:"foreignKey:MatchID"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	KickoffAt   time.Time      `json:"kickoff_at" gorm:"not null;index"`
+	TimeZone    string         `json:"time_zone" gorm:"size:64;not null"`
+	Competition string         `json:"competition" gorm:"size:100;not null;default:''"`
+	HomeTeamID  uint           `json:"home_team_id" gorm:"not null"`
+	AwayTeamID  uint           `json:"away_team_id" gorm:"not null;check:chk_matches_distinct_teams,home_team_id <> away_team_id"`
+	HomeScore   *int           `json:"home_score"`
+	AwayScore   *int           `json:"away_score"`
+	HomeTeam    *Team          `json:"home_team,omitempty" gorm:"foreignKey:HomeTeamID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	AwayTeam    *Team          `json:"away_team,omitempty" gorm:"foreignKey:AwayTeamID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Goals       []Goal         `json:"goals,omitempty" gorm:"foreignKey:MatchID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// Local layouts of the match date and time.
+const (
+	DateLayout = time.DateOnly
+	TimeLayout = "15:04"
+)
+
+// TimeLayouts are the layouts match times are read in: HH:MM, and HH:MM:SS
+// as older clients and databases wrote them.
+var TimeLayouts = []string{TimeLayout, time.TimeOnly}
+
+// Kickoff returns the kickoff in the time zone of the match.
+func (m *Match) Kickoff() time.Time {
+	return m.KickoffAt.In(Location(m.TimeZone))
+}
+
+// MatchDate is the local date of the match.
+func (m *Match) MatchDate() string {
+	return m.Kickoff().Format(DateLayout)
+}
+
+// MatchTime is the local kickoff time of the match.
+func (m *Match) MatchTime() string {
+	return m.Kickoff().Format(TimeLayout)
+}
+
+// MarshalJSON writes the kickoff both in UTC and in the time zone of the
+// match, along with the local date and time.
+func (m Match) MarshalJSON() ([]byte, error) {
+	type match Match
+	return json.Marshal(struct {
+		match
+		KickoffAt    time.Time `json:"kickoff_at"`
+		KickoffLocal time.Time `json:"kickoff_local"`
+		MatchDate    string    `json:"match_date"`
+		MatchTime    string    `json:"match_time"`
+	}{match(m), m.KickoffAt.UTC(), m.Kickoff(), m.MatchDate(), m.MatchTime()})
+}
+
+var locations sync.Map
+
+// Location loads the time zone called name, falling back to UTC for names
+// that are not known. Zones are loaded once.
+func Location(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+	locations.Store(name, loc)
+	return loc
 }
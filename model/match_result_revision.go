@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type MatchResultRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	MatchID   uint      `json:"match_id" gorm:"not null;uniqueIndex:idx_match_revision"`
+	Revision  int       `json:"revision" gorm:"not null;uniqueIndex:idx_match_revision"`
+	HomeScore int       `json:"home_score" gorm:"not null"`
+	AwayScore int       `json:"away_score" gorm:"not null"`
+	Goals     string    `json:"goals" gorm:"type:text;not null"`
+	Reason    string    `json:"reason" gorm:"type:text;not null"`
+	ChangedBy uint      `json:"changed_by" gorm:"not null"`
+	Match     *Match    `json:"-" gorm:"foreignKey:MatchID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at"`
+}
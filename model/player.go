@@ -14,7 +14,7 @@ type Player struct {
 	WeightKG     int            `json:"weight_kg"`
 	Position     string         `json:"position" gorm:"type:varchar(20);not null"`
 	JerseyNumber int            `json:"jersey_number" gorm:"not null"`
-	Team         *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	Team         *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
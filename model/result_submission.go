@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	SubmissionDraft     = "draft"
+	SubmissionSubmitted = "submitted"
+	SubmissionApproved  = "approved"
+	SubmissionRejected  = "rejected"
+)
+
+type ResultSubmission struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	MatchID     uint       `json:"match_id" gorm:"not null;index"`
+	Status      string     `json:"status" gorm:"size:20;not null;index"`
+	HomeScore   int        `json:"home_score" gorm:"not null"`
+	AwayScore   int        `json:"away_score" gorm:"not null"`
+	Goals       string     `json:"-" gorm:"type:text;not null"`
+	SubmittedBy uint       `json:"submitted_by" gorm:"not null"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	ReviewedBy  *uint      `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	ReviewNote  string     `json:"review_note" gorm:"type:text"`
+	Match       *Match     `json:"-" gorm:"foreignKey:MatchID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
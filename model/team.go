@@ -13,7 +13,7 @@ type Team struct {
 	FoundedYear int            `json:"founded_year" gorm:"not null"`
 	HQAddress   string         `json:"hq_address" gorm:"type:text"`
 	HQCity      string         `json:"hq_city" gorm:"size:255"`
-	Players     []Player       `json:"players,omitempty" gorm:"foreignKey:TeamID"`
+	Players     []Player       `json:"players,omitempty" gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
@@ -6,12 +6,23 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleReporter = "reporter"
+	RoleOfficial = "official"
+	RoleAdmin    = "admin"
+)
+
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Name      string         `json:"name" gorm:"size:255;not null"`
-	Email     string         `json:"email" gorm:"size:255;not null;uniqueIndex"`
-	Password  string         `json:"-" gorm:"size:255;not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID                      uint           `json:"id" gorm:"primaryKey"`
+	Name                    string         `json:"name" gorm:"size:255;not null"`
+	Email                   string         `json:"email" gorm:"size:255;not null;uniqueIndex"`
+	Password                string         `json:"-" gorm:"size:255;not null"`
+	Role                    string         `json:"role" gorm:"size:20;not null;default:reporter"`
+	PendingEmail            string         `json:"pending_email,omitempty" gorm:"size:255"`
+	EmailVerificationToken  string         `json:"-" gorm:"size:64;index"`
+	EmailVerificationExpiry *time.Time     `json:"-"`
+	TokenVersion            int            `json:"-" gorm:"not null;default:0"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
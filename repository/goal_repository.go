@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/pranotoism/football-go/model"
 	"gorm.io/gorm"
 )
@@ -13,28 +16,83 @@ func NewGoalRepository(db *gorm.DB) *GoalRepository {
 	return &GoalRepository{db: db}
 }
 
-func (r *GoalRepository) CreateBatch(goals []model.Goal) error {
+func (r *GoalRepository) CreateBatch(ctx context.Context, goals []model.Goal) error {
 	if len(goals) == 0 {
 		return nil
 	}
-	return r.db.Create(&goals).Error
+	return conn(ctx, r.db).Create(&goals).Error
 }
 
-func (r *GoalRepository) CreateBatchTx(tx *gorm.DB, goals []model.Goal) error {
-	if len(goals) == 0 {
-		return nil
+func (r *GoalRepository) FindByMatchID(ctx context.Context, matchID uint) ([]model.Goal, error) {
+	var goals []model.Goal
+	err := conn(ctx, r.db).Where("match_id = ?", matchID).
+		Preload("Player", withDeleted).Preload("Team", withDeleted).
+		Order("minute ASC").Find(&goals).Error
+	return goals, err
+}
+
+// FindAll lists goals with their scorer, team and match.
+func (r *GoalRepository) FindAll(ctx context.Context, q Query) (Page[model.Goal], error) {
+	var goals []model.Goal
+
+	query := conn(ctx, r.db).Model(&model.Goal{}).Scopes(GoalSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Goal]{}, err
 	}
-	return tx.Create(&goals).Error
+
+	err = query.Preload("Player", withDeleted).Preload("Team", withDeleted).Preload("Match", withDeleted).
+		Scopes(GoalSchema.page(q)).
+		Find(&goals).Error
+	if err != nil {
+		return Page[model.Goal]{}, err
+	}
+	return GoalSchema.Page(q, goals, total), nil
+}
+
+func (r *GoalRepository) DeleteByMatchID(ctx context.Context, matchID uint) error {
+	return conn(ctx, r.db).Where("match_id = ?", matchID).Delete(&model.Goal{}).Error
 }
 
-func (r *GoalRepository) FindByMatchID(matchID uint) ([]model.Goal, error) {
+func (r *GoalRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Goal, int64, error) {
 	var goals []model.Goal
-	err := r.db.Where("match_id = ?", matchID).
-		Preload("Player").Preload("Team").
-		Order("minute ASC").Find(&goals).Error
-	return goals, err
+	var total int64
+
+	query := conn(ctx, r.db).Unscoped().Model(&model.Goal{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Offset(offset).Limit(perPage).Order("deleted_at DESC").Find(&goals).Error
+	return goals, total, err
+}
+
+func (r *GoalRepository) RestoreByMatchID(ctx context.Context, matchID uint, deletedAt time.Time) error {
+	return conn(ctx, r.db).Unscoped().Model(&model.Goal{}).
+		Where("match_id = ? AND deleted_at = ?", matchID, deletedAt).
+		Update("deleted_at", nil).Error
+}
+
+func (r *GoalRepository) DeleteByTeamMatches(ctx context.Context, teamID uint) error {
+	db := conn(ctx, r.db)
+	matchIDs := db.Session(&gorm.Session{NewDB: true}).Model(&model.Match{}).Select("id").
+		Where("home_team_id = ? OR away_team_id = ?", teamID, teamID)
+	return db.Where("match_id IN (?)", matchIDs).Delete(&model.Goal{}).Error
+}
+
+func (r *GoalRepository) CountActiveByPlayerID(ctx context.Context, playerID uint) int64 {
+	var count int64
+	conn(ctx, r.db).Model(&model.Goal{}).Where("player_id = ?", playerID).Count(&count)
+	return count
+}
+
+func (r *GoalRepository) CountByPlayerID(ctx context.Context, playerID uint) int64 {
+	var count int64
+	conn(ctx, r.db).Unscoped().Model(&model.Goal{}).Where("player_id = ?", playerID).Count(&count)
+	return count
 }
 
-func (r *GoalRepository) DeleteByMatchID(matchID uint) error {
-	return r.db.Where("match_id = ?", matchID).Delete(&model.Goal{}).Error
+func (r *GoalRepository) PurgeByMatchID(ctx context.Context, matchID uint) error {
+	return conn(ctx, r.db).Unscoped().Where("match_id = ?", matchID).Delete(&model.Goal{}).Error
 }
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/pranotoism/football-go/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MatchRepository struct {
@@ -13,27 +17,47 @@ func NewMatchRepository(db *gorm.DB) *MatchRepository {
 	return &MatchRepository{db: db}
 }
 
-func (r *MatchRepository) Create(match *model.Match) error {
-	return r.db.Create(match).Error
+func (r *MatchRepository) Create(ctx context.Context, match *model.Match) error {
+	return conn(ctx, r.db).Create(match).Error
 }
 
-func (r *MatchRepository) FindAll(page, perPage int) ([]model.Match, int64, error) {
+func (r *MatchRepository) FindAll(ctx context.Context, q Query) (Page[model.Match], error) {
 	var matches []model.Match
-	var total int64
 
-	r.db.Model(&model.Match{}).Count(&total)
+	query := conn(ctx, r.db).Model(&model.Match{}).Scopes(MatchSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Match]{}, err
+	}
 
-	offset := (page - 1) * perPage
-	err := r.db.Preload("HomeTeam").Preload("AwayTeam").
-		Offset(offset).Limit(perPage).Order("match_date DESC, match_time DESC").
+	err = query.Preload("HomeTeam", withDeleted).Preload("AwayTeam", withDeleted).
+		Scopes(MatchSchema.page(q)).
 		Find(&matches).Error
-	return matches, total, err
+	if err != nil {
+		return Page[model.Match]{}, err
+	}
+	return MatchSchema.Page(q, matches, total), nil
+}
+
+func (r *MatchRepository) FindByID(ctx context.Context, id uint) (*model.Match, error) {
+	var match model.Match
+	err := conn(ctx, r.db).Preload("HomeTeam", withDeleted).Preload("AwayTeam", withDeleted).
+		Preload("Goals").Preload("Goals.Player", withDeleted).Preload("Goals.Team", withDeleted).
+		First(&match, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
 }
 
-func (r *MatchRepository) FindByID(id uint) (*model.Match, error) {
+// FindByIDForUpdate is FindByID that also locks the match until the
+// transaction of ctx ends. SQLite has no row locks; there the first write
+// locks the whole database instead.
+func (r *MatchRepository) FindByIDForUpdate(ctx context.Context, id uint) (*model.Match, error) {
 	var match model.Match
-	err := r.db.Preload("HomeTeam").Preload("AwayTeam").
-		Preload("Goals").Preload("Goals.Player").Preload("Goals.Team").
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Table: clause.Table{Name: clause.CurrentTable}}).
+		Preload("HomeTeam", withDeleted).Preload("AwayTeam", withDeleted).
+		Preload("Goals").Preload("Goals.Player", withDeleted).Preload("Goals.Team", withDeleted).
 		First(&match, id).Error
 	if err != nil {
 		return nil, err
@@ -41,38 +65,107 @@ func (r *MatchRepository) FindByID(id uint) (*model.Match, error) {
 	return &match, nil
 }
 
-func (r *MatchRepository) Update(match *model.Match) error {
-	return r.db.Save(match).Error
+func (r *MatchRepository) Update(ctx context.Context, match *model.Match) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Save(match).Error
 }
 
-func (r *MatchRepository) Delete(match *model.Match) error {
-	return r.db.Delete(match).Error
+func (r *MatchRepository) Delete(ctx context.Context, match *model.Match) error {
+	return conn(ctx, r.db).Delete(match).Error
 }
 
-func (r *MatchRepository) FindPlayedMatches(page, perPage int) ([]model.Match, int64, error) {
+func (r *MatchRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Match, int64, error) {
 	var matches []model.Match
 	var total int64
 
-	r.db.Model(&model.Match{}).Where("home_score IS NOT NULL").Count(&total)
+	query := conn(ctx, r.db).Unscoped().Model(&model.Match{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
 	offset := (page - 1) * perPage
-	err := r.db.Where("home_score IS NOT NULL").
-		Preload("HomeTeam").Preload("AwayTeam").
-		Preload("Goals").Preload("Goals.Player").Preload("Goals.Team").
-		Offset(offset).Limit(perPage).Order("match_date DESC, match_time DESC").
-		Find(&matches).Error
+	err := query.Offset(offset).Limit(perPage).Order("deleted_at DESC").Find(&matches).Error
 	return matches, total, err
 }
 
-func (r *MatchRepository) CountWins(teamID uint) int64 {
+func (r *MatchRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Match, error) {
+	var match model.Match
+	err := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&match, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *MatchRepository) Restore(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Model(&model.Match{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+func (r *MatchRepository) Purge(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Where("id = ?", id).Delete(&model.Match{}).Error
+}
+
+func (r *MatchRepository) CountActiveByTeamID(ctx context.Context, teamID uint) int64 {
+	var count int64
+	conn(ctx, r.db).Model(&model.Match{}).
+		Where("home_team_id = ? OR away_team_id = ?", teamID, teamID).
+		Count(&count)
+	return count
+}
+
+func (r *MatchRepository) SoftDeleteByTeamID(ctx context.Context, teamID uint) error {
+	return conn(ctx, r.db).Where("home_team_id = ? OR away_team_id = ?", teamID, teamID).Delete(&model.Match{}).Error
+}
+
+func (r *MatchRepository) FindDeletedByTeamIDAt(ctx context.Context, teamID uint, deletedAt time.Time) ([]model.Match, error) {
+	var matches []model.Match
+	err := conn(ctx, r.db).Unscoped().
+		Where("(home_team_id = ? OR away_team_id = ?) AND deleted_at = ?", teamID, teamID, deletedAt).
+		Find(&matches).Error
+	return matches, err
+}
+
+func (r *MatchRepository) CountByTeamID(ctx context.Context, teamID uint) int64 {
+	var count int64
+	conn(ctx, r.db).Unscoped().Model(&model.Match{}).
+		Where("home_team_id = ? OR away_team_id = ?", teamID, teamID).
+		Count(&count)
+	return count
+}
+
+// FindPlayedMatches lists the matches that have a result, along with their
+// goals, for reports.
+func (r *MatchRepository) FindPlayedMatches(ctx context.Context, q Query) (Page[model.Match], error) {
+	var matches []model.Match
+
+	q.Where(OpNotNull, nil, "home_score")
+	query := conn(ctx, r.db).Model(&model.Match{}).Scopes(MatchSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Match]{}, err
+	}
+
+	err = query.Preload("HomeTeam", withDeleted).Preload("AwayTeam", withDeleted).
+		Preload("Goals").Preload("Goals.Player", withDeleted).Preload("Goals.Team", withDeleted).
+		Scopes(MatchSchema.page(q)).
+		Find(&matches).Error
+	if err != nil {
+		return Page[model.Match]{}, err
+	}
+	return MatchSchema.Page(q, matches, total), nil
+}
+
+func (r *MatchRepository) CountWins(ctx context.Context, teamID uint) int64 {
 	var count int64
-	r.db.Model(&model.Match{}).
+	conn(ctx, r.db).Model(&model.Match{}).
 		Where("(home_team_id = ? AND home_score > away_score) OR (away_team_id = ? AND away_score > home_score)", teamID, teamID).
 		Where("home_score IS NOT NULL").
 		Count(&count)
 	return count
 }
 
-func (r *MatchRepository) DB() *gorm.DB {
-	return r.db
+func (r *MatchRepository) UpdateScore(ctx context.Context, id uint, homeScore, awayScore int) error {
+	return conn(ctx, r.db).Model(&model.Match{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"home_score": homeScore,
+		"away_score": awayScore,
+	}).Error
 }
@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+func createTeam(t *testing.T, db *gorm.DB, name string) *model.Team {
+	t.Helper()
+	team := &model.Team{Name: name, FoundedYear: 1990}
+	if err := db.Create(team).Error; err != nil {
+		t.Fatal(err)
+	}
+	return team
+}
+
+func createMatch(t *testing.T, db *gorm.DB, home, away *model.Team, scores ...int) *model.Match {
+	t.Helper()
+	match := &model.Match{KickoffAt: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC), TimeZone: "Asia/Jakarta", HomeTeamID: home.ID, AwayTeamID: away.ID}
+	if len(scores) == 2 {
+		match.HomeScore, match.AwayScore = &scores[0], &scores[1]
+	}
+	if err := db.Create(match).Error; err != nil {
+		t.Fatal(err)
+	}
+	return match
+}
+
+func TestMatchRepositoryCountWins(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewMatchRepository(db)
+
+	a := createTeam(t, db, "Arema")
+	b := createTeam(t, db, "Bali United")
+	createMatch(t, db, a, b, 2, 1)
+	createMatch(t, db, b, a, 0, 3)
+	createMatch(t, db, a, b, 1, 1)
+	createMatch(t, db, b, a, 2, 0)
+	createMatch(t, db, a, b)
+
+	deleted := createMatch(t, db, a, b, 5, 0)
+	if err := repo.Delete(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		team *model.Team
+		want int64
+	}{
+		{a, 2},
+		{b, 1},
+	}
+	for _, tt := range tests {
+		if got := repo.CountWins(ctx, tt.team.ID); got != tt.want {
+			t.Errorf("CountWins(%s) = %d, want %d", tt.team.Name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchRepositoryFindPlayedMatches(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewMatchRepository(db)
+
+	a := createTeam(t, db, "Arema")
+	b := createTeam(t, db, "Bali United")
+	createMatch(t, db, a, b, 2, 1)
+	createMatch(t, db, a, b)
+
+	matches, err := repo.FindPlayedMatches(ctx, repository.Query{Page: 1, PerPage: 10, CountTotal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *matches.Total != 1 || len(matches.Items) != 1 {
+		t.Fatalf("got %d matches (total %d), want 1", len(matches.Items), *matches.Total)
+	}
+	if matches.Items[0].HomeTeam == nil || matches.Items[0].HomeTeam.Name != "Arema" {
+		t.Errorf("home team not preloaded: %+v", matches.Items[0].HomeTeam)
+	}
+}
+
+func TestMatchRepositoryFindByIDForUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewMatchRepository(db)
+	match := createMatch(t, db, createTeam(t, db, "Arema"), createTeam(t, db, "Bali United"), 1, 0)
+
+	err := repository.NewTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := repo.FindByIDForUpdate(ctx, match.ID)
+		if err != nil {
+			return err
+		}
+		if locked.HomeTeam == nil || locked.HomeTeam.Name != "Arema" || *locked.HomeScore != 1 {
+			t.Errorf("locked match = %+v, want Arema 1-0 with its teams", locked)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FindByIDForUpdate(ctx, match.ID+1); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("missing match: error = %v, want %v", err, repository.ErrNotFound)
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pranotoism/football-go/model"
+	"gorm.io/gorm"
+)
+
+type MatchRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchRevisionRepository(db *gorm.DB) *MatchRevisionRepository {
+	return &MatchRevisionRepository{db: db}
+}
+
+func (r *MatchRevisionRepository) Create(ctx context.Context, revision *model.MatchResultRevision) error {
+	return conn(ctx, r.db).Create(revision).Error
+}
+
+func (r *MatchRevisionRepository) NextRevision(ctx context.Context, matchID uint) (int, error) {
+	var latest int
+	err := conn(ctx, r.db).Model(&model.MatchResultRevision{}).Where("match_id = ?", matchID).
+		Select("COALESCE(MAX(revision), 0)").Scan(&latest).Error
+	return latest + 1, err
+}
+
+func (r *MatchRevisionRepository) FindByMatchID(ctx context.Context, matchID uint) ([]model.MatchResultRevision, error) {
+	var revisions []model.MatchResultRevision
+	err := conn(ctx, r.db).Where("match_id = ?", matchID).Order("revision ASC").Find(&revisions).Error
+	return revisions, err
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+type GoalRepository struct {
+	s *Store
+}
+
+func (r *GoalRepository) CreateBatch(ctx context.Context, goals []model.Goal) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i := range goals {
+		goals[i].ID = r.s.goals.nextID()
+		goals[i].CreatedAt = now(ctx)
+		goals[i].UpdatedAt = goals[i].CreatedAt
+		goal := goals[i]
+		goal.Player, goal.Team, goal.Match = nil, nil, nil
+		r.s.goals.rows[goal.ID] = goal
+	}
+	return nil
+}
+
+func (r *GoalRepository) FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Goal], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	page := query(r.s.goals.sorted(func(g model.Goal) bool { return !g.DeletedAt.Valid }, nil), q, repository.GoalSchema)
+	for i := range page.Items {
+		goal := &page.Items[i]
+		if player, ok := r.s.players.rows[goal.PlayerID]; ok {
+			goal.Player = &player
+		}
+		if team, ok := r.s.teams.rows[goal.TeamID]; ok {
+			goal.Team = &team
+		}
+		if match, ok := r.s.matches.rows[goal.MatchID]; ok {
+			goal.Match = &match
+		}
+	}
+	return page, nil
+}
+
+func (r *GoalRepository) DeleteByMatchID(ctx context.Context, matchID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, g := range r.s.goals.rows {
+		if g.MatchID == matchID && !g.DeletedAt.Valid {
+			g.DeletedAt = deletedAt(ctx)
+			r.s.goals.rows[id] = g
+		}
+	}
+	return nil
+}
+
+func (r *GoalRepository) DeleteByTeamMatches(ctx context.Context, teamID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, g := range r.s.goals.rows {
+		match, ok := r.s.matches.rows[g.MatchID]
+		if ok && involves(match, teamID) && !match.DeletedAt.Valid && !g.DeletedAt.Valid {
+			g.DeletedAt = deletedAt(ctx)
+			r.s.goals.rows[id] = g
+		}
+	}
+	return nil
+}
+
+func (r *GoalRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Goal, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	goals := r.s.goals.sorted(func(g model.Goal) bool { return g.DeletedAt.Valid }, func(a, b model.Goal) bool {
+		return newerDeletion(a.DeletedAt, b.DeletedAt)
+	})
+	goals, total := paginate(goals, page, perPage)
+	return goals, total, nil
+}
+
+func (r *GoalRepository) RestoreByMatchID(ctx context.Context, matchID uint, deletedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, g := range r.s.goals.rows {
+		if g.MatchID == matchID && g.DeletedAt.Valid && g.DeletedAt.Time.Equal(deletedAt) {
+			g.DeletedAt = gorm.DeletedAt{}
+			r.s.goals.rows[id] = g
+		}
+	}
+	return nil
+}
+
+func (r *GoalRepository) CountActiveByPlayerID(ctx context.Context, playerID uint) int64 {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(r.s.goals.sorted(func(g model.Goal) bool {
+		return g.PlayerID == playerID && !g.DeletedAt.Valid
+	}, nil)))
+}
+
+func (r *GoalRepository) CountByPlayerID(ctx context.Context, playerID uint) int64 {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(r.s.goals.sorted(func(g model.Goal) bool { return g.PlayerID == playerID }, nil)))
+}
+
+func (r *GoalRepository) PurgeByMatchID(ctx context.Context, matchID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, g := range r.s.goals.rows {
+		if g.MatchID == matchID {
+			delete(r.s.goals.rows, id)
+		}
+	}
+	return nil
+}
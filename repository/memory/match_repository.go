@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+type MatchRepository struct {
+	s *Store
+}
+
+func (r *MatchRepository) Create(ctx context.Context, match *model.Match) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	match.ID = r.s.matches.nextID()
+	match.CreatedAt = now(ctx)
+	match.UpdatedAt = match.CreatedAt
+	r.s.matches.rows[match.ID] = stripMatch(*match)
+	return nil
+}
+
+func (r *MatchRepository) FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Match], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	page := query(r.s.matches.sorted(func(m model.Match) bool { return !m.DeletedAt.Valid }, nil), q, repository.MatchSchema)
+	for i := range page.Items {
+		r.loadTeams(&page.Items[i])
+	}
+	return page, nil
+}
+
+func (r *MatchRepository) FindByID(ctx context.Context, id uint) (*model.Match, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	match, ok := r.s.matches.rows[id]
+	if !ok || match.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	r.loadTeams(&match)
+	r.loadGoals(&match)
+	return &match, nil
+}
+
+// FindByIDForUpdate is FindByID, since transactions on the store already
+// run one at a time.
+func (r *MatchRepository) FindByIDForUpdate(ctx context.Context, id uint) (*model.Match, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *MatchRepository) Update(ctx context.Context, match *model.Match) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	match.UpdatedAt = time.Now()
+	r.s.matches.rows[match.ID] = stripMatch(*match)
+	return nil
+}
+
+func (r *MatchRepository) UpdateScore(ctx context.Context, id uint, homeScore, awayScore int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	match, ok := r.s.matches.rows[id]
+	if !ok || match.DeletedAt.Valid {
+		return nil
+	}
+	match.HomeScore = &homeScore
+	match.AwayScore = &awayScore
+	match.UpdatedAt = time.Now()
+	r.s.matches.rows[id] = match
+	return nil
+}
+
+func (r *MatchRepository) Delete(ctx context.Context, match *model.Match) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.softDelete(ctx, func(m model.Match) bool { return m.ID == match.ID })
+	return nil
+}
+
+func (r *MatchRepository) CountActiveByTeamID(ctx context.Context, teamID uint) int64 {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(r.s.matches.sorted(func(m model.Match) bool {
+		return involves(m, teamID) && !m.DeletedAt.Valid
+	}, nil)))
+}
+
+func (r *MatchRepository) CountByTeamID(ctx context.Context, teamID uint) int64 {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(r.s.matches.sorted(func(m model.Match) bool { return involves(m, teamID) }, nil)))
+}
+
+func (r *MatchRepository) SoftDeleteByTeamID(ctx context.Context, teamID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.softDelete(ctx, func(m model.Match) bool { return involves(m, teamID) })
+	return nil
+}
+
+func (r *MatchRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Match, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	matches := r.s.matches.sorted(func(m model.Match) bool { return m.DeletedAt.Valid }, func(a, b model.Match) bool {
+		return newerDeletion(a.DeletedAt, b.DeletedAt)
+	})
+	matches, total := paginate(matches, page, perPage)
+	return matches, total, nil
+}
+
+func (r *MatchRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Match, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	match, ok := r.s.matches.rows[id]
+	if !ok || !match.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	return &match, nil
+}
+
+func (r *MatchRepository) FindDeletedByTeamIDAt(ctx context.Context, teamID uint, deletedAt time.Time) ([]model.Match, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.matches.sorted(func(m model.Match) bool {
+		return involves(m, teamID) && m.DeletedAt.Valid && m.DeletedAt.Time.Equal(deletedAt)
+	}, nil), nil
+}
+
+func (r *MatchRepository) Restore(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if match, ok := r.s.matches.rows[id]; ok {
+		match.DeletedAt = gorm.DeletedAt{}
+		r.s.matches.rows[id] = match
+	}
+	return nil
+}
+
+func (r *MatchRepository) Purge(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.matches.rows, id)
+	return nil
+}
+
+func (r *MatchRepository) FindPlayedMatches(ctx context.Context, q repository.Query) (repository.Page[model.Match], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	page := query(r.s.matches.sorted(func(m model.Match) bool {
+		return m.HomeScore != nil && !m.DeletedAt.Valid
+	}, nil), q, repository.MatchSchema)
+	for i := range page.Items {
+		r.loadTeams(&page.Items[i])
+		r.loadGoals(&page.Items[i])
+	}
+	return page, nil
+}
+
+func (r *MatchRepository) CountWins(ctx context.Context, teamID uint) int64 {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var count int64
+	for _, m := range r.s.matches.rows {
+		if m.DeletedAt.Valid || m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		if (m.HomeTeamID == teamID && *m.HomeScore > *m.AwayScore) || (m.AwayTeamID == teamID && *m.AwayScore > *m.HomeScore) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *MatchRepository) softDelete(ctx context.Context, match func(model.Match) bool) {
+	for id, m := range r.s.matches.rows {
+		if match(m) && !m.DeletedAt.Valid {
+			m.DeletedAt = deletedAt(ctx)
+			r.s.matches.rows[id] = m
+		}
+	}
+}
+
+// loadTeams attaches both teams, including soft-deleted ones, like the
+// withDeleted preloads of the GORM repository.
+func (r *MatchRepository) loadTeams(match *model.Match) {
+	if team, ok := r.s.teams.rows[match.HomeTeamID]; ok {
+		match.HomeTeam = &team
+	}
+	if team, ok := r.s.teams.rows[match.AwayTeamID]; ok {
+		match.AwayTeam = &team
+	}
+}
+
+func (r *MatchRepository) loadGoals(match *model.Match) {
+	match.Goals = r.s.goals.sorted(func(g model.Goal) bool {
+		return g.MatchID == match.ID && !g.DeletedAt.Valid
+	}, nil)
+	for i := range match.Goals {
+		if player, ok := r.s.players.rows[match.Goals[i].PlayerID]; ok {
+			match.Goals[i].Player = &player
+		}
+		if team, ok := r.s.teams.rows[match.Goals[i].TeamID]; ok {
+			match.Goals[i].Team = &team
+		}
+	}
+}
+
+func involves(match model.Match, teamID uint) bool {
+	return match.HomeTeamID == teamID || match.AwayTeamID == teamID
+}
+
+func stripMatch(match model.Match) model.Match {
+	match.HomeTeam = nil
+	match.AwayTeam = nil
+	match.Goals = nil
+	return match
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+type PlayerRepository struct {
+	s *Store
+}
+
+func (r *PlayerRepository) Create(ctx context.Context, player *model.Player) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	player.ID = r.s.players.nextID()
+	player.CreatedAt = now(ctx)
+	player.UpdatedAt = player.CreatedAt
+	r.s.players.rows[player.ID] = stripPlayer(*player)
+	return nil
+}
+
+func (r *PlayerRepository) FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Player], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	page := query(r.s.players.sorted(func(p model.Player) bool { return !p.DeletedAt.Valid }, nil), q, repository.PlayerSchema)
+	for i := range page.Items {
+		if team, ok := r.s.teams.rows[page.Items[i].TeamID]; ok {
+			page.Items[i].Team = &team
+		}
+	}
+	return page, nil
+}
+
+func (r *PlayerRepository) FindByTeam(ctx context.Context, teamID uint, q repository.Query) (repository.Page[model.Player], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return query(r.s.players.sorted(func(p model.Player) bool {
+		return p.TeamID == teamID && !p.DeletedAt.Valid
+	}, nil), q, repository.PlayerSchema), nil
+}
+
+func (r *PlayerRepository) FindByID(ctx context.Context, id uint) (*model.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	player, ok := r.s.players.rows[id]
+	if !ok || player.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	if team, ok := r.s.teams.rows[player.TeamID]; ok && !team.DeletedAt.Valid {
+		player.Team = &team
+	}
+	return &player, nil
+}
+
+func (r *PlayerRepository) Update(ctx context.Context, player *model.Player) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	player.UpdatedAt = time.Now()
+	r.s.players.rows[player.ID] = stripPlayer(*player)
+	return nil
+}
+
+func (r *PlayerRepository) Delete(ctx context.Context, player *model.Player) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.softDelete(ctx, func(p model.Player) bool { return p.ID == player.ID })
+	return nil
+}
+
+func (r *PlayerRepository) IsJerseyNumberTaken(ctx context.Context, teamID uint, jerseyNumber int, excludePlayerID uint) bool {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, p := range r.s.players.rows {
+		if p.TeamID == teamID && p.JerseyNumber == jerseyNumber && p.ID != excludePlayerID && !p.DeletedAt.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PlayerRepository) SoftDeleteByTeamID(ctx context.Context, teamID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.softDelete(ctx, func(p model.Player) bool { return p.TeamID == teamID })
+	return nil
+}
+
+func (r *PlayerRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Player, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	players := r.s.players.sorted(func(p model.Player) bool { return p.DeletedAt.Valid }, func(a, b model.Player) bool {
+		return newerDeletion(a.DeletedAt, b.DeletedAt)
+	})
+	players, total := paginate(players, page, perPage)
+	return players, total, nil
+}
+
+func (r *PlayerRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	player, ok := r.s.players.rows[id]
+	if !ok || !player.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	return &player, nil
+}
+
+func (r *PlayerRepository) Restore(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.restore(func(p model.Player) bool { return p.ID == id })
+	return nil
+}
+
+func (r *PlayerRepository) RestoreByTeamID(ctx context.Context, teamID uint, deletedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.restore(func(p model.Player) bool { return p.TeamID == teamID && p.DeletedAt.Time.Equal(deletedAt) })
+	return nil
+}
+
+func (r *PlayerRepository) Purge(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.players.rows, id)
+	return nil
+}
+
+func (r *PlayerRepository) PurgeByTeamID(ctx context.Context, teamID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, p := range r.s.players.rows {
+		if p.TeamID == teamID {
+			delete(r.s.players.rows, id)
+		}
+	}
+	return nil
+}
+
+func (r *PlayerRepository) softDelete(ctx context.Context, match func(model.Player) bool) {
+	for id, p := range r.s.players.rows {
+		if match(p) && !p.DeletedAt.Valid {
+			p.DeletedAt = deletedAt(ctx)
+			r.s.players.rows[id] = p
+		}
+	}
+}
+
+func (r *PlayerRepository) restore(match func(model.Player) bool) {
+	for id, p := range r.s.players.rows {
+		if match(p) && p.DeletedAt.Valid {
+			p.DeletedAt = gorm.DeletedAt{}
+			r.s.players.rows[id] = p
+		}
+	}
+}
+
+func stripPlayer(player model.Player) model.Player {
+	player.Team = nil
+	return player
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pranotoism/football-go/repository"
+)
+
+// query evaluates q against rows the way the GORM repositories do, reading
+// field values through the schema.
+func query[T any](rows []T, q repository.Query, schema repository.Schema[T]) repository.Page[T] {
+	var kept []T
+	for _, row := range rows {
+		if matchesAll(schema, row, q.Conditions) {
+			kept = append(kept, row)
+		}
+	}
+
+	var total *int64
+	if q.CountTotal {
+		n := int64(len(kept))
+		total = &n
+	}
+
+	order := schema.Order(q)
+	if q.Cursor != nil {
+		var after []T
+		for _, row := range kept {
+			c := compareRow(schema, order, row, q.Cursor.Values)
+			if (c > 0 && !q.Cursor.Backward) || (c < 0 && q.Cursor.Backward) {
+				after = append(after, row)
+			}
+		}
+		kept = after
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		c := compareRow(schema, order, kept[i], values(schema, order, kept[j]))
+		if q.Backward() {
+			c = -c
+		}
+		return c < 0
+	})
+
+	start := min(q.Offset(), len(kept))
+	end := min(start+q.PerPage+1, len(kept))
+	return schema.Page(q, kept[start:end], total)
+}
+
+func values[T any](schema repository.Schema[T], order []repository.Sort, row T) []any {
+	result := make([]any, len(order))
+	for i, s := range order {
+		result[i] = field(schema, s.Field)(row)
+	}
+	return result
+}
+
+// compareRow compares row with the sort values of another row in the given
+// order.
+func compareRow[T any](schema repository.Schema[T], order []repository.Sort, row T, other []any) int {
+	for i, s := range order {
+		c := compare(field(schema, s.Field)(row), other[i])
+		if s.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func field[T any](schema repository.Schema[T], name string) func(T) any {
+	f, ok := schema.Fields[name]
+	if !ok {
+		panic(fmt.Sprintf("memory: unknown field %q", name))
+	}
+	return f.Value
+}
+
+func matchesAll[T any](schema repository.Schema[T], row T, conditions []repository.Condition) bool {
+	for _, c := range conditions {
+		ok := false
+		for _, name := range c.Fields {
+			if matches(field(schema, name)(row), c.Op, c.Value) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func matches(value any, op repository.Op, want any) bool {
+	switch op {
+	case repository.OpNull:
+		return value == nil
+	case repository.OpNotNull:
+		return value != nil
+	}
+	if value == nil {
+		return false
+	}
+
+	switch op {
+	case repository.OpContains:
+		return strings.Contains(strings.ToLower(value.(string)), strings.ToLower(fmt.Sprint(want)))
+	case repository.OpGte:
+		return compare(value, want) >= 0
+	case repository.OpLte:
+		return compare(value, want) <= 0
+	case repository.OpLt:
+		return compare(value, want) < 0
+	default:
+		return compare(value, want) == 0
+	}
+}
+
+// compare orders two values of the same type, with nil first.
+func compare(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		return cmp.Compare(a, b.(int))
+	case uint:
+		return cmp.Compare(a, b.(uint))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	default:
+		panic(fmt.Sprintf("memory: cannot compare %T", a))
+	}
+}
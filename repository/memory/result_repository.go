@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+type MatchRevisionRepository struct {
+	s *Store
+}
+
+func (r *MatchRevisionRepository) Create(ctx context.Context, revision *model.MatchResultRevision) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	revision.ID = r.s.revisions.nextID()
+	revision.CreatedAt = time.Now()
+	stored := *revision
+	stored.Match = nil
+	r.s.revisions.rows[revision.ID] = stored
+	return nil
+}
+
+func (r *MatchRevisionRepository) NextRevision(ctx context.Context, matchID uint) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	latest := 0
+	for _, rev := range r.s.revisions.rows {
+		if rev.MatchID == matchID && rev.Revision > latest {
+			latest = rev.Revision
+		}
+	}
+	return latest + 1, nil
+}
+
+func (r *MatchRevisionRepository) FindByMatchID(ctx context.Context, matchID uint) ([]model.MatchResultRevision, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.revisions.sorted(func(rev model.MatchResultRevision) bool { return rev.MatchID == matchID },
+		func(a, b model.MatchResultRevision) bool { return a.Revision < b.Revision }), nil
+}
+
+type ResultSubmissionRepository struct {
+	s *Store
+}
+
+func (r *ResultSubmissionRepository) Create(ctx context.Context, submission *model.ResultSubmission) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.openTaken(*submission) {
+		return repository.ErrDuplicate
+	}
+	submission.ID = r.s.submissions.nextID()
+	submission.CreatedAt = time.Now()
+	submission.UpdatedAt = submission.CreatedAt
+	r.s.submissions.rows[submission.ID] = stripSubmission(*submission)
+	return nil
+}
+
+func (r *ResultSubmissionRepository) FindByID(ctx context.Context, id uint) (*model.ResultSubmission, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	submission, ok := r.s.submissions.rows[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &submission, nil
+}
+
+func (r *ResultSubmissionRepository) FindByMatchID(ctx context.Context, matchID uint) ([]model.ResultSubmission, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.submissions.sorted(func(s model.ResultSubmission) bool { return s.MatchID == matchID },
+		func(a, b model.ResultSubmission) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+func (r *ResultSubmissionRepository) FindByStatus(ctx context.Context, status string, page, perPage int) ([]model.ResultSubmission, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	submissions := r.s.submissions.sorted(func(s model.ResultSubmission) bool { return s.Status == status },
+		func(a, b model.ResultSubmission) bool {
+			if a.SubmittedAt == nil || b.SubmittedAt == nil {
+				return a.SubmittedAt != nil
+			}
+			return a.SubmittedAt.Before(*b.SubmittedAt)
+		})
+	submissions, total := paginate(submissions, page, perPage)
+	return submissions, total, nil
+}
+
+func (r *ResultSubmissionRepository) HasOpenSubmission(ctx context.Context, matchID uint) bool {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, s := range r.s.submissions.rows {
+		if s.MatchID == matchID && open(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ResultSubmissionRepository) Transition(ctx context.Context, submission *model.ResultSubmission, from string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if stored, ok := r.s.submissions.rows[submission.ID]; !ok || stored.Status != from {
+		return repository.ErrNotFound
+	}
+	if r.openTaken(*submission) {
+		return repository.ErrDuplicate
+	}
+	submission.UpdatedAt = time.Now()
+	r.s.submissions.rows[submission.ID] = stripSubmission(*submission)
+	return nil
+}
+
+// openTaken reports whether submission is open while another submission
+// for its match is, which the unique index idx_result_submissions_open
+// forbids.
+func (r *ResultSubmissionRepository) openTaken(submission model.ResultSubmission) bool {
+	if !open(submission) {
+		return false
+	}
+	for _, s := range r.s.submissions.rows {
+		if s.ID != submission.ID && s.MatchID == submission.MatchID && open(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func open(submission model.ResultSubmission) bool {
+	return submission.Status == model.SubmissionDraft || submission.Status == model.SubmissionSubmitted
+}
+
+func stripSubmission(submission model.ResultSubmission) model.ResultSubmission {
+	submission.Match = nil
+	return submission
+}
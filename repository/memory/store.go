@@ -0,0 +1,167 @@
+// Package memory implements the service repositories on top of plain maps,
+// so services can be tested without a database. Soft deletes, preloaded
+// associations and transaction rollback behave like the GORM repositories;
+// foreign keys and most other database constraints are not enforced, save
+// the unique indexes services rely on.
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Store holds every record and hands out repositories that share it. It is
+// also a repository.Transactor: a transaction that fails is rolled back by
+// restoring a snapshot taken when it began. Transactions run one at a time,
+// as if each locked every row it reads; calls outside them are not
+// isolated.
+type Store struct {
+	mu          sync.Mutex
+	txMu        sync.Mutex
+	users       table[model.User]
+	teams       table[model.Team]
+	players     table[model.Player]
+	matches     table[model.Match]
+	goals       table[model.Goal]
+	revisions   table[model.MatchResultRevision]
+	submissions table[model.ResultSubmission]
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:       newTable[model.User](),
+		teams:       newTable[model.Team](),
+		players:     newTable[model.Player](),
+		matches:     newTable[model.Match](),
+		goals:       newTable[model.Goal](),
+		revisions:   newTable[model.MatchResultRevision](),
+		submissions: newTable[model.ResultSubmission](),
+	}
+}
+
+func (s *Store) Users() *UserRepository                   { return &UserRepository{s: s} }
+func (s *Store) Teams() *TeamRepository                   { return &TeamRepository{s: s} }
+func (s *Store) Players() *PlayerRepository               { return &PlayerRepository{s: s} }
+func (s *Store) Matches() *MatchRepository                { return &MatchRepository{s: s} }
+func (s *Store) Goals() *GoalRepository                   { return &GoalRepository{s: s} }
+func (s *Store) Revisions() *MatchRevisionRepository      { return &MatchRevisionRepository{s: s} }
+func (s *Store) Submissions() *ResultSubmissionRepository { return &ResultSubmissionRepository{s: s} }
+
+func (s *Store) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	snapshot := s.snapshot()
+	s.mu.Unlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		s.mu.Lock()
+		s.restore(snapshot)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *Store) snapshot() *Store {
+	return &Store{
+		users:       s.users.clone(),
+		teams:       s.teams.clone(),
+		players:     s.players.clone(),
+		matches:     s.matches.clone(),
+		goals:       s.goals.clone(),
+		revisions:   s.revisions.clone(),
+		submissions: s.submissions.clone(),
+	}
+}
+
+func (s *Store) restore(snapshot *Store) {
+	s.users = snapshot.users
+	s.teams = snapshot.teams
+	s.players = snapshot.players
+	s.matches = snapshot.matches
+	s.goals = snapshot.goals
+	s.revisions = snapshot.revisions
+	s.submissions = snapshot.submissions
+}
+
+// table stores the rows of one model by primary key. Rows are kept without
+// their associations, which are filled in when a row is read.
+type table[T any] struct {
+	rows   map[uint]T
+	lastID uint
+}
+
+func newTable[T any]() table[T] {
+	return table[T]{rows: make(map[uint]T)}
+}
+
+func (t *table[T]) nextID() uint {
+	t.lastID++
+	return t.lastID
+}
+
+func (t table[T]) clone() table[T] {
+	return table[T]{rows: maps.Clone(t.rows), lastID: t.lastID}
+}
+
+// sorted returns the rows accepted by keep ordered by less, or by primary
+// key when less is nil.
+func (t table[T]) sorted(keep func(T) bool, less func(a, b T) bool) []T {
+	ids := make([]uint, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	result := []T{}
+	for _, id := range ids {
+		if row := t.rows[id]; keep == nil || keep(row) {
+			result = append(result, row)
+		}
+	}
+	if less != nil {
+		sort.SliceStable(result, func(i, j int) bool { return less(result[i], result[j]) })
+	}
+	return result
+}
+
+func paginate[T any](rows []T, page, perPage int) ([]T, int64) {
+	total := int64(len(rows))
+	start := (page - 1) * perPage
+	if start >= len(rows) {
+		return []T{}, total
+	}
+	end := min(start+perPage, len(rows))
+	return rows[start:end], total
+}
+
+// now returns the time to stamp on records, which is the deletion time
+// fixed by repository.WithDeletionTime when there is one.
+func now(ctx context.Context) time.Time {
+	if deletedAt, ok := repository.DeletionTime(ctx); ok {
+		return deletedAt
+	}
+	return time.Now()
+}
+
+func deletedAt(ctx context.Context) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: now(ctx), Valid: true}
+}
+
+func newerDeletion(a, b gorm.DeletedAt) bool {
+	return a.Time.After(b.Time)
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"gorm.io/gorm"
+)
+
+type TeamRepository struct {
+	s *Store
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *model.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team.ID = r.s.teams.nextID()
+	team.CreatedAt = now(ctx)
+	team.UpdatedAt = team.CreatedAt
+	r.s.teams.rows[team.ID] = stripTeam(*team)
+	return nil
+}
+
+func (r *TeamRepository) FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Team], error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return query(r.s.teams.sorted(func(t model.Team) bool { return !t.DeletedAt.Valid }, nil), q, repository.TeamSchema), nil
+}
+
+func (r *TeamRepository) FindByID(ctx context.Context, id uint) (*model.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team, ok := r.s.teams.rows[id]
+	if !ok || team.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	team.Players = r.s.players.sorted(func(p model.Player) bool {
+		return p.TeamID == id && !p.DeletedAt.Valid
+	}, nil)
+	return &team, nil
+}
+
+func (r *TeamRepository) Update(ctx context.Context, team *model.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team.UpdatedAt = time.Now()
+	r.s.teams.rows[team.ID] = stripTeam(*team)
+	return nil
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, team *model.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := r.s.teams.rows[team.ID]
+	if !ok || t.DeletedAt.Valid {
+		return nil
+	}
+	t.DeletedAt = deletedAt(ctx)
+	r.s.teams.rows[team.ID] = t
+	return nil
+}
+
+func (r *TeamRepository) Exists(ctx context.Context, id uint) bool {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team, ok := r.s.teams.rows[id]
+	return ok && !team.DeletedAt.Valid
+}
+
+func (r *TeamRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Team, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teams := r.s.teams.sorted(func(t model.Team) bool { return t.DeletedAt.Valid }, func(a, b model.Team) bool {
+		return newerDeletion(a.DeletedAt, b.DeletedAt)
+	})
+	teams, total := paginate(teams, page, perPage)
+	return teams, total, nil
+}
+
+func (r *TeamRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team, ok := r.s.teams.rows[id]
+	if !ok || !team.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	return &team, nil
+}
+
+func (r *TeamRepository) Restore(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if team, ok := r.s.teams.rows[id]; ok {
+		team.DeletedAt = gorm.DeletedAt{}
+		r.s.teams.rows[id] = team
+	}
+	return nil
+}
+
+func (r *TeamRepository) Purge(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.teams.rows, id)
+	return nil
+}
+
+func stripTeam(team model.Team) model.Team {
+	team.Players = nil
+	return team
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+type UserRepository struct {
+	s *Store
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, u := range r.s.users.rows {
+		if u.Email == user.Email {
+			return errors.New("duplicate key value violates unique constraint idx_users_email")
+		}
+	}
+
+	user.ID = r.s.users.nextID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	if user.Role == "" {
+		user.Role = model.RoleReporter
+	}
+	r.s.users.rows[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	return r.find(func(u model.User) bool { return u.ID == id })
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	return r.find(func(u model.User) bool { return u.Email == email })
+}
+
+func (r *UserRepository) FindByEmailVerificationToken(ctx context.Context, token string) (*model.User, error) {
+	return r.find(func(u model.User) bool { return u.EmailVerificationToken == token })
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *model.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user.UpdatedAt = time.Now()
+	r.s.users.rows[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, user *model.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users.rows[user.ID]
+	if !ok {
+		return nil
+	}
+	u.DeletedAt = deletedAt(ctx)
+	r.s.users.rows[user.ID] = u
+	return nil
+}
+
+func (r *UserRepository) find(match func(model.User) bool) (*model.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	users := r.s.users.sorted(func(u model.User) bool { return !u.DeletedAt.Valid && match(u) }, nil)
+	if len(users) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &users[0], nil
+}
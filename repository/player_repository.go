@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/pranotoism/football-go/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PlayerRepository struct {
@@ -13,41 +17,62 @@ func NewPlayerRepository(db *gorm.DB) *PlayerRepository {
 	return &PlayerRepository{db: db}
 }
 
-func (r *PlayerRepository) Create(player *model.Player) error {
-	return r.db.Create(player).Error
+func (r *PlayerRepository) Create(ctx context.Context, player *model.Player) error {
+	return conn(ctx, r.db).Create(player).Error
 }
 
-func (r *PlayerRepository) FindByTeam(teamID uint, page, perPage int) ([]model.Player, int64, error) {
+// FindAll lists the players of all teams, each with its team.
+func (r *PlayerRepository) FindAll(ctx context.Context, q Query) (Page[model.Player], error) {
 	var players []model.Player
-	var total int64
 
-	r.db.Model(&model.Player{}).Where("team_id = ?", teamID).Count(&total)
+	query := conn(ctx, r.db).Model(&model.Player{}).Scopes(PlayerSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Player]{}, err
+	}
 
-	offset := (page - 1) * perPage
-	err := r.db.Where("team_id = ?", teamID).Offset(offset).Limit(perPage).Find(&players).Error
-	return players, total, err
+	err = query.Preload("Team", withDeleted).Scopes(PlayerSchema.page(q)).Find(&players).Error
+	if err != nil {
+		return Page[model.Player]{}, err
+	}
+	return PlayerSchema.Page(q, players, total), nil
+}
+
+func (r *PlayerRepository) FindByTeam(ctx context.Context, teamID uint, q Query) (Page[model.Player], error) {
+	var players []model.Player
+
+	query := conn(ctx, r.db).Model(&model.Player{}).Where("team_id = ?", teamID).Scopes(PlayerSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Player]{}, err
+	}
+
+	if err := query.Scopes(PlayerSchema.page(q)).Find(&players).Error; err != nil {
+		return Page[model.Player]{}, err
+	}
+	return PlayerSchema.Page(q, players, total), nil
 }
 
-func (r *PlayerRepository) FindByID(id uint) (*model.Player, error) {
+func (r *PlayerRepository) FindByID(ctx context.Context, id uint) (*model.Player, error) {
 	var player model.Player
-	err := r.db.Preload("Team").First(&player, id).Error
+	err := conn(ctx, r.db).Preload("Team").First(&player, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &player, nil
 }
 
-func (r *PlayerRepository) Update(player *model.Player) error {
-	return r.db.Save(player).Error
+func (r *PlayerRepository) Update(ctx context.Context, player *model.Player) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Save(player).Error
 }
 
-func (r *PlayerRepository) Delete(player *model.Player) error {
-	return r.db.Delete(player).Error
+func (r *PlayerRepository) Delete(ctx context.Context, player *model.Player) error {
+	return conn(ctx, r.db).Delete(player).Error
 }
 
-func (r *PlayerRepository) IsJerseyNumberTaken(teamID uint, jerseyNumber int, excludePlayerID uint) bool {
+func (r *PlayerRepository) IsJerseyNumberTaken(ctx context.Context, teamID uint, jerseyNumber int, excludePlayerID uint) bool {
 	var count int64
-	query := r.db.Model(&model.Player{}).Where("team_id = ? AND jersey_number = ?", teamID, jerseyNumber)
+	query := conn(ctx, r.db).Model(&model.Player{}).Where("team_id = ? AND jersey_number = ?", teamID, jerseyNumber)
 	if excludePlayerID > 0 {
 		query = query.Where("id != ?", excludePlayerID)
 	}
@@ -55,6 +80,47 @@ func (r *PlayerRepository) IsJerseyNumberTaken(teamID uint, jerseyNumber int, ex
 	return count > 0
 }
 
-func (r *PlayerRepository) SoftDeleteByTeamID(teamID uint) error {
-	return r.db.Where("team_id = ?", teamID).Delete(&model.Player{}).Error
+func (r *PlayerRepository) SoftDeleteByTeamID(ctx context.Context, teamID uint) error {
+	return conn(ctx, r.db).Where("team_id = ?", teamID).Delete(&model.Player{}).Error
+}
+
+func (r *PlayerRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Player, int64, error) {
+	var players []model.Player
+	var total int64
+
+	query := conn(ctx, r.db).Unscoped().Model(&model.Player{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Offset(offset).Limit(perPage).Order("deleted_at DESC").Find(&players).Error
+	return players, total, err
+}
+
+func (r *PlayerRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Player, error) {
+	var player model.Player
+	err := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&player, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+func (r *PlayerRepository) Restore(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Model(&model.Player{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+func (r *PlayerRepository) RestoreByTeamID(ctx context.Context, teamID uint, deletedAt time.Time) error {
+	return conn(ctx, r.db).Unscoped().Model(&model.Player{}).
+		Where("team_id = ? AND deleted_at = ?", teamID, deletedAt).
+		Update("deleted_at", nil).Error
+}
+
+func (r *PlayerRepository) Purge(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Where("id = ?", id).Delete(&model.Player{}).Error
+}
+
+func (r *PlayerRepository) PurgeByTeamID(ctx context.Context, teamID uint) error {
+	return conn(ctx, r.db).Unscoped().Where("team_id = ?", teamID).Delete(&model.Player{}).Error
 }
@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+func TestPlayerRepositoryRestoreByTeamID(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewPlayerRepository(db)
+
+	team := createTeam(t, db, "Persib")
+	for i := 1; i <= 3; i++ {
+		if err := repo.Create(ctx, &model.Player{TeamID: team.ID, Name: "Player", Position: "gelandang", JerseyNumber: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Delete one player on its own, then the rest together as a team
+	// deletion would.
+	if err := db.Delete(&model.Player{}, "jersey_number = ?", 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SoftDeleteByTeamID(repository.WithDeletionTime(ctx, time.Now()), team.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, _, err := repo.FindDeleted(ctx, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deletedAt time.Time
+	for _, p := range deleted {
+		if p.JerseyNumber == 2 {
+			deletedAt = p.DeletedAt.Time
+		}
+	}
+
+	if err := repo.RestoreByTeamID(ctx, team.ID, deletedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	players, err := repo.FindByTeam(ctx, team.ID, repository.Query{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players.Items) != 2 {
+		t.Errorf("restored %d players, want 2", len(players.Items))
+	}
+	if repo.IsJerseyNumberTaken(ctx, team.ID, 1, 0) {
+		t.Error("separately deleted player was restored as well")
+	}
+}
@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pranotoism/football-go/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidCursor is returned for a cursor that is malformed or was issued
+// for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Op is the comparison a Condition makes.
+type Op int
+
+const (
+	OpEq Op = iota
+	// OpContains matches strings containing Value, ignoring case.
+	OpContains
+	OpGte
+	OpLte
+	OpLt
+	OpNull
+	OpNotNull
+)
+
+// Condition compares fields with Value. With more than one field it holds
+// when any of them matches, e.g. a match involving a team as home or away
+// side.
+type Condition struct {
+	Fields []string
+	Op     Op
+	Value  any
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Query filters, orders and pages a list. It refers to the logical field
+// names of a Schema rather than to columns, so request parameters never
+// reach the SQL. Services build queries; the GORM repositories and the
+// in-memory store both evaluate them.
+//
+// Without a Cursor the list is paged by offset. With one, it continues
+// after (or, going backward, before) the row the cursor was taken from,
+// which stays stable while rows are added.
+type Query struct {
+	Conditions []Condition
+	Sort       []Sort
+	Page       int
+	PerPage    int
+	Cursor     *Cursor
+	CountTotal bool
+}
+
+// Where adds a condition on one or more fields.
+func (q *Query) Where(op Op, value any, fields ...string) {
+	q.Conditions = append(q.Conditions, Condition{Fields: fields, Op: op, Value: value})
+}
+
+// Offset returns the number of rows to skip, which is zero when paging by
+// cursor.
+func (q Query) Offset() int {
+	if q.Cursor != nil || q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PerPage
+}
+
+// Backward reports whether q pages toward the start of the list.
+func (q Query) Backward() bool {
+	return q.Cursor != nil && q.Cursor.Backward
+}
+
+// Cursor is the position of a row in a sorted list: its values for each
+// sort field, with the sort order they belong to.
+type Cursor struct {
+	Order    string
+	Values   []any
+	Backward bool
+}
+
+type cursorJSON struct {
+	Order    string            `json:"o"`
+	Values   []json.RawMessage `json:"v"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+// Page is one page of a list. Total is nil when the rows were not counted.
+// Next and Prev are cursors for the adjacent pages, empty when there are
+// none.
+type Page[T any] struct {
+	Items []T
+	Total *int64
+	Next  string
+	Prev  string
+}
+
+// Field is a field of T that queries may filter on, and sort by when
+// Sortable.
+type Field[T any] struct {
+	Column   string
+	Sortable bool
+	Value    func(T) any
+}
+
+// Schema lists the fields of a model that a Query may use.
+type Schema[T any] struct {
+	Fields      map[string]Field[T]
+	DefaultSort []Sort
+}
+
+var TeamSchema = Schema[model.Team]{
+	Fields: map[string]Field[model.Team]{
+		"id":           {"teams.id", true, func(t model.Team) any { return t.ID }},
+		"name":         {"teams.name", true, func(t model.Team) any { return t.Name }},
+		"city":         {"teams.hq_city", true, func(t model.Team) any { return t.HQCity }},
+		"founded_year": {"teams.founded_year", true, func(t model.Team) any { return t.FoundedYear }},
+		"created_at":   {"teams.created_at", true, func(t model.Team) any { return t.CreatedAt }},
+	},
+	DefaultSort: []Sort{{Field: "id"}},
+}
+
+var PlayerSchema = Schema[model.Player]{
+	Fields: map[string]Field[model.Player]{
+		"id":            {"players.id", true, func(p model.Player) any { return p.ID }},
+		"team_id":       {"players.team_id", false, func(p model.Player) any { return p.TeamID }},
+		"name":          {"players.name", true, func(p model.Player) any { return p.Name }},
+		"position":      {"players.position", true, func(p model.Player) any { return p.Position }},
+		"jersey_number": {"players.jersey_number", true, func(p model.Player) any { return p.JerseyNumber }},
+		"height_cm":     {"players.height_cm", true, func(p model.Player) any { return p.HeightCM }},
+		"weight_kg":     {"players.weight_kg", true, func(p model.Player) any { return p.WeightKG }},
+		"created_at":    {"players.created_at", true, func(p model.Player) any { return p.CreatedAt }},
+	},
+	DefaultSort: []Sort{{Field: "id"}},
+}
+
+var MatchSchema = Schema[model.Match]{
+	Fields: map[string]Field[model.Match]{
+		"id":           {"matches.id", true, func(m model.Match) any { return m.ID }},
+		"kickoff_at":   {"matches.kickoff_at", true, func(m model.Match) any { return m.KickoffAt.UTC() }},
+		"home_team_id": {"matches.home_team_id", false, func(m model.Match) any { return m.HomeTeamID }},
+		"away_team_id": {"matches.away_team_id", false, func(m model.Match) any { return m.AwayTeamID }},
+		"home_score": {"matches.home_score", false, func(m model.Match) any {
+			if m.HomeScore == nil {
+				return nil
+			}
+			return *m.HomeScore
+		}},
+		"created_at": {"matches.created_at", true, func(m model.Match) any { return m.CreatedAt }},
+	},
+	DefaultSort: []Sort{{Field: "kickoff_at", Desc: true}},
+}
+
+var GoalSchema = Schema[model.Goal]{
+	Fields: map[string]Field[model.Goal]{
+		"id":         {"goals.id", true, func(g model.Goal) any { return g.ID }},
+		"match_id":   {"goals.match_id", false, func(g model.Goal) any { return g.MatchID }},
+		"player_id":  {"goals.player_id", false, func(g model.Goal) any { return g.PlayerID }},
+		"team_id":    {"goals.team_id", false, func(g model.Goal) any { return g.TeamID }},
+		"minute":     {"goals.minute", true, func(g model.Goal) any { return g.Minute }},
+		"created_at": {"goals.created_at", true, func(g model.Goal) any { return g.CreatedAt }},
+	},
+	DefaultSort: []Sort{{Field: "id"}},
+}
+
+// Sortable returns the fields that may be sorted by, in alphabetical order.
+func (s Schema[T]) Sortable() []string {
+	var fields []string
+	for _, name := range slices.Sorted(maps.Keys(s.Fields)) {
+		if s.Fields[name].Sortable {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// ParseSort parses a comma-separated list of sortable fields, each
+// optionally prefixed with "-" for descending order, e.g.
+// "-founded_year,name".
+func (s Schema[T]) ParseSort(param string) ([]Sort, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	var sorts []Sort
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(param, ",") {
+		part = strings.TrimSpace(part)
+		field, desc := strings.CutPrefix(part, "-")
+		if !s.Fields[field].Sortable {
+			return nil, fmt.Errorf("cannot sort by %q, sortable fields are %s", part, strings.Join(s.Sortable(), ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%q is sorted by more than once", field)
+		}
+		seen[field] = true
+		sorts = append(sorts, Sort{Field: field, Desc: desc})
+	}
+	return sorts, nil
+}
+
+// Order returns the sort order of q: its own or the schema default,
+// followed by id so that every row has a unique position, which offsets
+// and cursors rely on.
+func (s Schema[T]) Order(q Query) []Sort {
+	sorts := q.Sort
+	if len(sorts) == 0 {
+		sorts = s.DefaultSort
+	}
+	if slices.ContainsFunc(sorts, func(s Sort) bool { return s.Field == "id" }) {
+		return sorts
+	}
+	return append(slices.Clip(sorts), Sort{Field: "id"})
+}
+
+func orderKey(order []Sort) string {
+	parts := make([]string, len(order))
+	for i, s := range order {
+		parts[i] = s.Field
+		if s.Desc {
+			parts[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// DecodeCursor parses an opaque cursor and sets it on q. It fails with
+// ErrInvalidCursor unless the cursor was issued for the sort order of q.
+func (s Schema[T]) DecodeCursor(token string, q *Query) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	var raw cursorJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ErrInvalidCursor
+	}
+
+	order := s.Order(*q)
+	if raw.Order != orderKey(order) || len(raw.Values) != len(order) {
+		return fmt.Errorf("%w: it belongs to a different sort order", ErrInvalidCursor)
+	}
+
+	var zero T
+	cursor := &Cursor{Order: raw.Order, Backward: raw.Backward, Values: make([]any, len(order))}
+	for i, sort := range order {
+		sample := s.Fields[sort.Field].Value(zero)
+		if sample == nil {
+			return ErrInvalidCursor
+		}
+		value := reflect.New(reflect.TypeOf(sample))
+		if err := json.Unmarshal(raw.Values[i], value.Interface()); err != nil {
+			return ErrInvalidCursor
+		}
+		cursor.Values[i] = value.Elem().Interface()
+	}
+	q.Cursor = cursor
+	return nil
+}
+
+func (s Schema[T]) encodeCursor(row T, order []Sort, backward bool) string {
+	raw := cursorJSON{Order: orderKey(order), Backward: backward}
+	for _, sort := range order {
+		value, _ := json.Marshal(s.Fields[sort.Field].Value(row))
+		raw.Values = append(raw.Values, value)
+	}
+	data, _ := json.Marshal(raw)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Page builds the page for q from rows, which must hold up to PerPage+1
+// rows in the order they were fetched in; the extra row only tells whether
+// there is more to come.
+func (s Schema[T]) Page(q Query, rows []T, total *int64) Page[T] {
+	more := len(rows) > q.PerPage
+	if more {
+		rows = rows[:q.PerPage]
+	}
+	if q.Backward() {
+		slices.Reverse(rows)
+	}
+
+	page := Page[T]{Items: rows, Total: total}
+	if len(rows) == 0 {
+		return page
+	}
+
+	hasNext, hasPrev := more, q.Page > 1
+	switch {
+	case q.Backward():
+		hasNext, hasPrev = true, more
+	case q.Cursor != nil:
+		hasPrev = true
+	}
+
+	order := s.Order(q)
+	if hasNext {
+		page.Next = s.encodeCursor(rows[len(rows)-1], order, false)
+	}
+	if hasPrev {
+		page.Prev = s.encodeCursor(rows[0], order, true)
+	}
+	return page
+}
+
+// filter is a GORM scope applying the conditions of q, without its cursor.
+func (s Schema[T]) filter(q Query) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, c := range q.Conditions {
+			var exprs []clause.Expression
+			for _, field := range c.Fields {
+				f, ok := s.Fields[field]
+				if !ok {
+					db.AddError(fmt.Errorf("query: unknown field %q", field))
+					return db
+				}
+				exprs = append(exprs, condition(f.Column, c.Op, c.Value))
+			}
+			db = db.Where(clause.Or(exprs...))
+		}
+		return db
+	}
+}
+
+func condition(column string, op Op, value any) clause.Expression {
+	col := clause.Column{Name: column, Raw: true}
+	switch op {
+	case OpContains:
+		pattern := "%" + escapeLike(strings.ToLower(fmt.Sprint(value))) + "%"
+		return clause.Expr{SQL: "LOWER(?) LIKE ? ESCAPE '\\'", Vars: []any{col, pattern}}
+	case OpGte:
+		return clause.Gte{Column: col, Value: value}
+	case OpLte:
+		return clause.Lte{Column: col, Value: value}
+	case OpLt:
+		return clause.Lt{Column: col, Value: value}
+	case OpNull:
+		return clause.Eq{Column: col, Value: nil}
+	case OpNotNull:
+		return clause.Neq{Column: col, Value: nil}
+	default:
+		return clause.Eq{Column: col, Value: value}
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// page is a GORM scope that continues after the cursor of q, if any, and
+// applies its sort order and page size, fetching one extra row for Page.
+// Going backward, rows are fetched in reverse order.
+func (s Schema[T]) page(q Query) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		order := s.Order(q)
+		columns := make([]clause.OrderByColumn, len(order))
+		for i, sort := range order {
+			f, ok := s.Fields[sort.Field]
+			if !ok {
+				db.AddError(fmt.Errorf("query: unknown field %q", sort.Field))
+				return db
+			}
+			columns[i] = clause.OrderByColumn{Column: clause.Column{Name: f.Column, Raw: true}, Desc: sort.Desc != q.Backward()}
+		}
+		if q.Cursor != nil {
+			db = db.Where(s.keyset(order, q.Cursor))
+		}
+		return db.Clauses(clause.OrderBy{Columns: columns}).
+			Offset(q.Offset()).Limit(q.PerPage + 1)
+	}
+}
+
+// keyset matches the rows after the cursor in the given order, or before
+// it when going backward: (a > x) OR (a = x AND b > y) and so on.
+func (s Schema[T]) keyset(order []Sort, cursor *Cursor) clause.Expression {
+	ors := make([]clause.Expression, len(order))
+	for i, sort := range order {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := range i {
+			ands = append(ands, clause.Eq{Column: s.column(order[j].Field), Value: cursor.Values[j]})
+		}
+		if sort.Desc == cursor.Backward {
+			ands = append(ands, clause.Gt{Column: s.column(sort.Field), Value: cursor.Values[i]})
+		} else {
+			ands = append(ands, clause.Lt{Column: s.column(sort.Field), Value: cursor.Values[i]})
+		}
+		ors[i] = clause.And(ands...)
+	}
+	return clause.Or(ors...)
+}
+
+func (s Schema[T]) column(field string) clause.Column {
+	return clause.Column{Name: s.Fields[field].Column, Raw: true}
+}
+
+// count counts the rows matched by db when q asks for a total.
+func count(db *gorm.DB, q Query) (*int64, error) {
+	if !q.CountTotal {
+		return nil, nil
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
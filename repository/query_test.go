@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/repository/memory"
+)
+
+type listRepos struct {
+	teams interface {
+		Create(ctx context.Context, team *model.Team) error
+		FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Team], error)
+	}
+	players interface {
+		Create(ctx context.Context, player *model.Player) error
+		FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Player], error)
+		FindByTeam(ctx context.Context, teamID uint, q repository.Query) (repository.Page[model.Player], error)
+	}
+	matches interface {
+		Create(ctx context.Context, match *model.Match) error
+		FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Match], error)
+	}
+}
+
+// TestQueriesAgreeAcrossRepositories runs the same queries against the GORM
+// repositories and the in-memory store, which services are tested with.
+func TestQueriesAgreeAcrossRepositories(t *testing.T) {
+	db := databasetest.New(t)
+	store := memory.NewStore()
+	impls := map[string]listRepos{
+		"gorm":   {repository.NewTeamRepository(db), repository.NewPlayerRepository(db), repository.NewMatchRepository(db)},
+		"memory": {store.Teams(), store.Players(), store.Matches()},
+	}
+
+	ctx := context.Background()
+	for name, repos := range impls {
+		t.Run(name, func(t *testing.T) {
+			seedLists(t, ctx, repos)
+
+			teamTests := []struct {
+				name  string
+				query repository.Query
+				want  []uint
+			}{
+				{"default order", query(nil), []uint{1, 2, 3, 4}},
+				{"name contains, ignoring case", query(nil, repository.Condition{Fields: []string{"name"}, Op: repository.OpContains, Value: "PERSI"}), []uint{1, 2}},
+				{"city", query(nil, repository.Condition{Fields: []string{"city"}, Op: repository.OpContains, Value: "bandung"}), []uint{1}},
+				{"wildcards are literal", query(nil, repository.Condition{Fields: []string{"name"}, Op: repository.OpContains, Value: "%"}), nil},
+				{"founded range", query(nil,
+					repository.Condition{Fields: []string{"founded_year"}, Op: repository.OpGte, Value: 1930},
+					repository.Condition{Fields: []string{"founded_year"}, Op: repository.OpLte, Value: 1990},
+				), []uint{1, 2}},
+				{"multi-field sort", query([]repository.Sort{{Field: "founded_year", Desc: true}, {Field: "name"}}), []uint{3, 4, 1, 2}},
+			}
+			for _, tt := range teamTests {
+				teams, err := repos.teams.FindAll(ctx, tt.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := ids(teams.Items, teamID); !slices.Equal(got, tt.want) || *teams.Total != int64(len(tt.want)) {
+					t.Errorf("teams %s = %v (total %d), want %v", tt.name, got, *teams.Total, tt.want)
+				}
+			}
+
+			players, err := repos.players.FindByTeam(ctx, 1, query([]repository.Sort{{Field: "jersey_number", Desc: true}},
+				repository.Condition{Fields: []string{"position"}, Op: repository.OpEq, Value: "penyerang"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(players.Items, func(p model.Player) uint { return p.ID }); !slices.Equal(got, []uint{3, 1}) {
+				t.Errorf("players = %v, want [3 1]", got)
+			}
+
+			players, err = repos.players.FindAll(ctx, query([]repository.Sort{{Field: "name"}},
+				repository.Condition{Fields: []string{"team_id"}, Op: repository.OpEq, Value: uint(2)}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(players.Items, func(p model.Player) uint { return p.ID }); !slices.Equal(got, []uint{5, 4}) || players.Items[0].Team == nil {
+				t.Errorf("players of team 2 = %v, want [5 4] with their team", got)
+			}
+
+			matchTests := []struct {
+				name  string
+				query repository.Query
+				want  []uint
+			}{
+				{"latest first", query(nil), []uint{3, 2, 1}},
+				{"team involvement", query(nil, repository.Condition{Fields: []string{"home_team_id", "away_team_id"}, Op: repository.OpEq, Value: uint(3)}), []uint{3, 2}},
+				{"played", query(nil, repository.Condition{Fields: []string{"home_score"}, Op: repository.OpNotNull}), []uint{2, 1}},
+				{"unplayed", query(nil, repository.Condition{Fields: []string{"home_score"}, Op: repository.OpNull}), []uint{3}},
+				{"since", query([]repository.Sort{{Field: "kickoff_at"}},
+					repository.Condition{Fields: []string{"kickoff_at"}, Op: repository.OpGte, Value: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+				), []uint{2, 3}},
+				{"before", query([]repository.Sort{{Field: "kickoff_at"}},
+					repository.Condition{Fields: []string{"kickoff_at"}, Op: repository.OpLt, Value: time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)},
+				), []uint{1, 2}},
+			}
+			for _, tt := range matchTests {
+				matches, err := repos.matches.FindAll(ctx, tt.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := ids(matches.Items, matchID); !slices.Equal(got, tt.want) {
+					t.Errorf("matches %s = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+
+			walkTests := []struct {
+				name  string
+				sorts []repository.Sort
+				want  [][]uint
+			}{
+				{"ties broken by id", []repository.Sort{{Field: "founded_year"}}, [][]uint{{1, 2}, {4, 3}}},
+				{"descending", []repository.Sort{{Field: "founded_year", Desc: true}, {Field: "name"}}, [][]uint{{3, 4}, {1, 2}}},
+			}
+			for _, tt := range walkTests {
+				if pages := walkTeams(t, ctx, repos, tt.sorts); !slices.EqualFunc(pages, tt.want, slices.Equal) {
+					t.Errorf("teams by cursor, %s = %v, want %v", tt.name, pages, tt.want)
+				}
+			}
+
+			q := repository.Query{PerPage: 2}
+			first, err := repos.matches.FindAll(ctx, q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := repository.MatchSchema.DecodeCursor(first.Next, &q); err != nil {
+				t.Fatal(err)
+			}
+			rest, err := repos.matches.FindAll(ctx, q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(rest.Items, matchID); !slices.Equal(got, []uint{1}) || rest.Total != nil || rest.Next != "" {
+				t.Errorf("matches after %v = %v (next %q), want [1]", ids(first.Items, matchID), got, rest.Next)
+			}
+			if err := repository.TeamSchema.DecodeCursor(first.Next, &q); err == nil {
+				t.Error("match cursor accepted for teams")
+			}
+		})
+	}
+}
+
+// walkTeams pages through all teams by cursor and then back again from the
+// last page, and returns the pages seen going forward. It fails the test if
+// the pages going back differ.
+func walkTeams(t *testing.T, ctx context.Context, repos listRepos, sorts []repository.Sort) [][]uint {
+	t.Helper()
+
+	var forward, backward [][]uint
+	q := repository.Query{Sort: sorts, Page: 1, PerPage: 2}
+	for {
+		page, err := repos.teams.FindAll(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q.Backward() {
+			backward = append([][]uint{ids(page.Items, teamID)}, backward...)
+		} else {
+			forward = append(forward, ids(page.Items, teamID))
+		}
+
+		token := page.Next
+		if q.Backward() || token == "" {
+			token = page.Prev
+		}
+		if token == "" {
+			break
+		}
+		if err := repository.TeamSchema.DecodeCursor(token, &q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := forward[:len(forward)-1]; !slices.EqualFunc(backward, want, slices.Equal) {
+		t.Errorf("pages going back = %v, want %v", backward, want)
+	}
+	return forward
+}
+
+func TestParseSort(t *testing.T) {
+	sorts, err := repository.TeamSchema.ParseSort("-founded_year, name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []repository.Sort{{Field: "founded_year", Desc: true}, {Field: "name"}}
+	if !slices.Equal(sorts, want) {
+		t.Errorf("ParseSort = %v, want %v", sorts, want)
+	}
+
+	for _, param := range []string{"hq_address", "name,-name", "name;drop table teams"} {
+		if _, err := repository.TeamSchema.ParseSort(param); err == nil {
+			t.Errorf("ParseSort(%q) succeeded, want an error", param)
+		}
+	}
+}
+
+func seedLists(t *testing.T, ctx context.Context, repos listRepos) {
+	t.Helper()
+	for _, team := range []model.Team{
+		{Name: "Persib", HQCity: "Bandung", FoundedYear: 1933},
+		{Name: "Persija", HQCity: "Jakarta", FoundedYear: 1933},
+		{Name: "Bali United", HQCity: "Gianyar", FoundedYear: 2015},
+		{Name: "Madura United", HQCity: "Pamekasan", FoundedYear: 2004},
+	} {
+		if err := repos.teams.Create(ctx, &team); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, player := range []model.Player{
+		{TeamID: 1, Name: "Ciro", Position: "penyerang", JerseyNumber: 9},
+		{TeamID: 1, Name: "Marc", Position: "gelandang", JerseyNumber: 8},
+		{TeamID: 1, Name: "David", Position: "penyerang", JerseyNumber: 19},
+		{TeamID: 2, Name: "Riko", Position: "gelandang", JerseyNumber: 7},
+		{TeamID: 2, Name: "Macan", Position: "bertahan", JerseyNumber: 3},
+	} {
+		if err := repos.players.Create(ctx, &player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	one, two := 1, 2
+	for _, match := range []model.Match{
+		{KickoffAt: time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC), TimeZone: "Asia/Jakarta", HomeTeamID: 1, AwayTeamID: 2, HomeScore: &one, AwayScore: &two},
+		{KickoffAt: time.Date(2026, 2, 10, 8, 30, 0, 0, time.UTC), TimeZone: "Asia/Jakarta", HomeTeamID: 3, AwayTeamID: 1, HomeScore: &two, AwayScore: &one},
+		{KickoffAt: time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC), TimeZone: "Asia/Jakarta", HomeTeamID: 4, AwayTeamID: 3},
+	} {
+		if err := repos.matches.Create(ctx, &match); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func query(sorts []repository.Sort, conditions ...repository.Condition) repository.Query {
+	return repository.Query{Conditions: conditions, Sort: sorts, Page: 1, PerPage: 10, CountTotal: true}
+}
+
+func ids[T any](rows []T, id func(T) uint) []uint {
+	var result []uint
+	for _, row := range rows {
+		result = append(result, id(row))
+	}
+	return result
+}
+
+func teamID(t model.Team) uint   { return t.ID }
+func matchID(m model.Match) uint { return m.ID }
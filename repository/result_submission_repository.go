@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pranotoism/football-go/model"
+	"gorm.io/gorm"
+)
+
+type ResultSubmissionRepository struct {
+	db *gorm.DB
+}
+
+func NewResultSubmissionRepository(db *gorm.DB) *ResultSubmissionRepository {
+	return &ResultSubmissionRepository{db: db}
+}
+
+// Create returns ErrDuplicate when submission is open and its match
+// already has an open submission.
+func (r *ResultSubmissionRepository) Create(ctx context.Context, submission *model.ResultSubmission) error {
+	return translate(r.db, conn(ctx, r.db).Create(submission).Error)
+}
+
+func (r *ResultSubmissionRepository) FindByID(ctx context.Context, id uint) (*model.ResultSubmission, error) {
+	var submission model.ResultSubmission
+	err := conn(ctx, r.db).First(&submission, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *ResultSubmissionRepository) FindByMatchID(ctx context.Context, matchID uint) ([]model.ResultSubmission, error) {
+	var submissions []model.ResultSubmission
+	err := conn(ctx, r.db).Where("match_id = ?", matchID).Order("created_at DESC").Find(&submissions).Error
+	return submissions, err
+}
+
+func (r *ResultSubmissionRepository) FindByStatus(ctx context.Context, status string, page, perPage int) ([]model.ResultSubmission, int64, error) {
+	var submissions []model.ResultSubmission
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.ResultSubmission{}).Where("status = ?", status)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Offset(offset).Limit(perPage).Order("submitted_at ASC").Find(&submissions).Error
+	return submissions, total, err
+}
+
+func (r *ResultSubmissionRepository) HasOpenSubmission(ctx context.Context, matchID uint) bool {
+	var count int64
+	conn(ctx, r.db).Model(&model.ResultSubmission{}).
+		Where("match_id = ? AND status IN ?", matchID, []string{model.SubmissionDraft, model.SubmissionSubmitted}).
+		Count(&count)
+	return count > 0
+}
+
+// Transition saves submission, which was read in status from. It returns
+// ErrNotFound when the stored submission has left that status since, and
+// ErrDuplicate when submission reopens while its match has another open
+// submission.
+func (r *ResultSubmissionRepository) Transition(ctx context.Context, submission *model.ResultSubmission, from string) error {
+	result := conn(ctx, r.db).Model(submission).Where("status = ?", from).Select("*").Updates(submission)
+	if result.Error != nil {
+		return translate(r.db, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
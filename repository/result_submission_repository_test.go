@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+func TestResultSubmissionRepositoryOneOpenPerMatch(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewResultSubmissionRepository(db)
+	match := createMatch(t, db, createTeam(t, db, "Persib"), createTeam(t, db, "Persija"))
+
+	submission := func(status string) *model.ResultSubmission {
+		return &model.ResultSubmission{MatchID: match.ID, Status: status, Goals: "[]", SubmittedBy: 1}
+	}
+	first := submission(model.SubmissionSubmitted)
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, submission(model.SubmissionDraft)); !errors.Is(err, repository.ErrDuplicate) {
+		t.Errorf("second open submission: error = %v, want %v", err, repository.ErrDuplicate)
+	}
+	rejected := submission(model.SubmissionRejected)
+	if err := repo.Create(ctx, rejected); err != nil {
+		t.Fatalf("closed submission: %v", err)
+	}
+
+	rejected.Status = model.SubmissionDraft
+	if err := repo.Transition(ctx, rejected, model.SubmissionRejected); !errors.Is(err, repository.ErrDuplicate) {
+		t.Errorf("reopening beside an open submission: error = %v, want %v", err, repository.ErrDuplicate)
+	}
+}
+
+func TestResultSubmissionRepositoryTransition(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewResultSubmissionRepository(db)
+	match := createMatch(t, db, createTeam(t, db, "Persib"), createTeam(t, db, "Persija"))
+
+	submission := &model.ResultSubmission{MatchID: match.ID, Status: model.SubmissionSubmitted, Goals: "[]", SubmittedBy: 1}
+	if err := repo.Create(ctx, submission); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two reviewers read the submission while it was submitted
+	approved, rejected := *submission, *submission
+	approved.Status, rejected.Status = model.SubmissionApproved, model.SubmissionRejected
+	if err := repo.Transition(ctx, &approved, model.SubmissionSubmitted); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Transition(ctx, &rejected, model.SubmissionSubmitted); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("stale transition: error = %v, want %v", err, repository.ErrNotFound)
+	}
+
+	stored, err := repo.FindByID(ctx, submission.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != model.SubmissionApproved {
+		t.Errorf("status = %q, want %q", stored.Status, model.SubmissionApproved)
+	}
+}
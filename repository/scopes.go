@@ -0,0 +1,10 @@
+package repository
+
+import "gorm.io/gorm"
+
+// withDeleted is used when preloading referenced records for historical
+// data, such as the teams of a past match, which must stay visible after
+// the referenced record has been soft-deleted.
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
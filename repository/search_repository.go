@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SearchTeam   = "team"
+	SearchPlayer = "player"
+	// SearchVenue finds teams by the address of their headquarters, which
+	// is where they play their home matches.
+	SearchVenue = "venue"
+)
+
+var SearchTypes = []string{SearchTeam, SearchPlayer, SearchVenue}
+
+type SearchQuery struct {
+	Text  string
+	Types []string
+	Limit int
+}
+
+// SearchHit is an entity matching a search. Detail places it, e.g. the
+// team of a player, and TeamID is the team it belongs to.
+type SearchHit struct {
+	Type   string
+	ID     uint
+	Name   string
+	Detail string
+	TeamID uint
+	Score  float64
+}
+
+// SearchRepository searches the names of teams, players and venues. On
+// Postgres it matches words with the football_search text search
+// configuration, which ignores accents, and tolerates typos by trigram
+// similarity; the indexes both rely on are created by migration 0006. On
+// SQLite it falls back to LIKE, ranking exact matches over prefixes over
+// matches anywhere in the name.
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// trigramThreshold is the word similarity from which a name is taken to
+// match despite typos. The pg_trgm default of 0.6 misses a single swapped
+// pair of letters in a short name, e.g. "persbi" for "Persib".
+const trigramThreshold = "0.3"
+
+// The tsvector expressions match the expression indexes of migration 0006.
+var postgresSearches = map[string]string{
+	SearchTeam: `
+		SELECT 'team' AS type, t.id, t.name, coalesce(t.hq_city, '') AS detail, t.id AS team_id,
+			ts_rank(setweight(to_tsvector('football_search', t.name), 'A') ||
+				setweight(to_tsvector('football_search', coalesce(t.hq_city, '')), 'B'), q.query)
+			+ word_similarity(q.folded, search_fold(t.name)) AS score
+		FROM teams t, q
+		WHERE t.deleted_at IS NULL AND (
+			(setweight(to_tsvector('football_search', t.name), 'A') ||
+				setweight(to_tsvector('football_search', coalesce(t.hq_city, '')), 'B')) @@ q.query
+			OR search_fold(t.name) %> q.folded)`,
+	SearchPlayer: `
+		SELECT 'player' AS type, p.id, p.name, t.name AS detail, t.id AS team_id,
+			ts_rank(to_tsvector('football_search', p.name), q.query)
+			+ word_similarity(q.folded, search_fold(p.name)) AS score
+		FROM players p JOIN teams t ON t.id = p.team_id AND t.deleted_at IS NULL, q
+		WHERE p.deleted_at IS NULL AND (
+			to_tsvector('football_search', p.name) @@ q.query
+			OR search_fold(p.name) %> q.folded)`,
+	SearchVenue: `
+		SELECT 'venue' AS type, t.id, t.hq_address AS name, t.name AS detail, t.id AS team_id,
+			ts_rank(to_tsvector('football_search', coalesce(t.hq_address, '')), q.query)
+			+ word_similarity(q.folded, search_fold(coalesce(t.hq_address, ''))) AS score
+		FROM teams t, q
+		WHERE t.deleted_at IS NULL AND t.hq_address <> '' AND (
+			to_tsvector('football_search', coalesce(t.hq_address, '')) @@ q.query
+			OR search_fold(coalesce(t.hq_address, '')) %> q.folded)`,
+}
+
+var sqliteSearches = map[string]string{
+	SearchTeam: `
+		SELECT 'team' AS type, id, name, coalesce(hq_city, '') AS detail, id AS team_id,
+			` + likeScore("name") + ` AS score
+		FROM teams
+		WHERE deleted_at IS NULL AND (name LIKE @contains ESCAPE '\' OR hq_city LIKE @contains ESCAPE '\')`,
+	SearchPlayer: `
+		SELECT 'player' AS type, p.id, p.name, t.name AS detail, t.id AS team_id,
+			` + likeScore("p.name") + ` AS score
+		FROM players p JOIN teams t ON t.id = p.team_id AND t.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL AND p.name LIKE @contains ESCAPE '\'`,
+	SearchVenue: `
+		SELECT 'venue' AS type, id, hq_address AS name, name AS detail, id AS team_id,
+			` + likeScore("hq_address") + ` AS score
+		FROM teams
+		WHERE deleted_at IS NULL AND hq_address <> '' AND hq_address LIKE @contains ESCAPE '\'`,
+}
+
+// likeScore ranks a LIKE match on column, with a city-only team match
+// scoring lowest.
+func likeScore(column string) string {
+	return `CASE
+				WHEN lower(` + column + `) = @text THEN 1.0
+				WHEN ` + column + ` LIKE @prefix ESCAPE '\' THEN 0.75
+				WHEN ` + column + ` LIKE @contains ESCAPE '\' THEN 0.5
+				ELSE 0.25
+			END`
+}
+
+// Search returns up to q.Limit hits of the requested types, best first.
+// Without types it searches all of them.
+func (r *SearchRepository) Search(ctx context.Context, q SearchQuery) ([]SearchHit, error) {
+	searches, prelude := sqliteSearches, ""
+	if r.db.Dialector.Name() == "postgres" {
+		searches = postgresSearches
+		prelude = `WITH q AS (
+			SELECT websearch_to_tsquery('football_search', @text) AS query, search_fold(@text) AS folded
+		) `
+	}
+
+	types := q.Types
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, searches[t])
+	}
+
+	text := strings.ToLower(strings.TrimSpace(q.Text))
+	sql := prelude + strings.Join(parts, " UNION ALL ") + " ORDER BY score DESC, name, type, id LIMIT @limit"
+
+	vars := map[string]any{
+		"text":     text,
+		"prefix":   escapeLike(text) + "%",
+		"contains": "%" + escapeLike(text) + "%",
+		"limit":    q.Limit,
+	}
+
+	var hits []SearchHit
+	db := conn(ctx, r.db)
+	if prelude == "" {
+		err := db.Raw(sql, vars).Scan(&hits).Error
+		return hits, err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL pg_trgm.word_similarity_threshold = " + trigramThreshold).Error; err != nil {
+			return err
+		}
+		return tx.Raw(sql, vars).Scan(&hits).Error
+	})
+	return hits, err
+}
@@ -0,0 +1,116 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+// TestSearchRepositoryFallback covers the LIKE search used on SQLite.
+func TestSearchRepositoryFallback(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := repository.NewSearchRepository(db)
+
+	persib := &model.Team{Name: "Persib", HQCity: "Bandung", HQAddress: "Stadion Gelora Bandung Lautan Api", FoundedYear: 1933}
+	persis := &model.Team{Name: "Persis Solo", HQCity: "Surakarta", FoundedYear: 1923}
+	arema := &model.Team{Name: "Arema", HQCity: "Malang", HQAddress: "Stadion Kanjuruhan", FoundedYear: 1987}
+	for _, team := range []*model.Team{persib, persis, arema} {
+		if err := db.Create(team).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	player := &model.Player{TeamID: arema.ID, Name: "Dendi Persada", Position: "gelandang", JerseyNumber: 8}
+	if err := db.Create(player).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&model.Player{TeamID: persib.ID, Name: "Dimas 100% Drajad", Position: "penyerang", JerseyNumber: 9}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(persis).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query repository.SearchQuery
+		want  []repository.SearchHit
+	}{
+		{"exact before prefix before anywhere", repository.SearchQuery{Text: "PERSIB", Limit: 10}, []repository.SearchHit{
+			{Type: "team", ID: persib.ID, Name: "Persib", Detail: "Bandung", TeamID: persib.ID, Score: 1},
+		}},
+		{"across types", repository.SearchQuery{Text: "pers", Limit: 10}, []repository.SearchHit{
+			{Type: "team", ID: persib.ID, Name: "Persib", Detail: "Bandung", TeamID: persib.ID, Score: 0.75},
+			{Type: "player", ID: player.ID, Name: "Dendi Persada", Detail: "Arema", TeamID: arema.ID, Score: 0.5},
+		}},
+		{"type filter and city", repository.SearchQuery{Text: "bandung", Types: []string{repository.SearchTeam}, Limit: 10}, []repository.SearchHit{
+			{Type: "team", ID: persib.ID, Name: "Persib", Detail: "Bandung", TeamID: persib.ID, Score: 0.25},
+		}},
+		{"venue", repository.SearchQuery{Text: "kanjuruhan", Limit: 10}, []repository.SearchHit{
+			{Type: "venue", ID: arema.ID, Name: "Stadion Kanjuruhan", Detail: "Arema", TeamID: arema.ID, Score: 0.5},
+		}},
+		{"limit", repository.SearchQuery{Text: "stadion", Limit: 1}, []repository.SearchHit{
+			{Type: "venue", ID: persib.ID, Name: "Stadion Gelora Bandung Lautan Api", Detail: "Persib", TeamID: persib.ID, Score: 0.75},
+		}},
+		{"wildcards are literal", repository.SearchQuery{Text: "0%", Limit: 10}, []repository.SearchHit{
+			{Type: "player", ID: 2, Name: "Dimas 100% Drajad", Detail: "Persib", TeamID: persib.ID, Score: 0.5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := repo.Search(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hits) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", hits, tt.want)
+			}
+			for i := range hits {
+				if hits[i] != tt.want[i] {
+					t.Errorf("hit %d = %+v, want %+v", i, hits[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSearchRepositoryPostgres covers the full-text and trigram search. It
+// runs against the server named by TEST_POSTGRES_DSN.
+func TestSearchRepositoryPostgres(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.Postgres(t)
+	repo := repository.NewSearchRepository(db)
+
+	persib := &model.Team{Name: "Persib", HQCity: "Bandung", FoundedYear: 1933}
+	arema := &model.Team{Name: "Arema", HQCity: "Malang", HQAddress: "Stadion Kanjuruhan", FoundedYear: 1987}
+	for _, team := range []*model.Team{persib, arema} {
+		if err := db.Create(team).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query repository.SearchQuery
+		want  uint
+	}{
+		{"exact", repository.SearchQuery{Text: "Persib", Types: []string{repository.SearchTeam}, Limit: 5}, persib.ID},
+		// A swapped pair of letters falls below the pg_trgm default threshold
+		{"swapped letters", repository.SearchQuery{Text: "persbi", Types: []string{repository.SearchTeam}, Limit: 5}, persib.ID},
+		{"missing letter", repository.SearchQuery{Text: "arma", Types: []string{repository.SearchTeam}, Limit: 5}, arema.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := repo.Search(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hits) == 0 || hits[0].ID != tt.want {
+				t.Errorf("Search(%q) = %+v, want team %d first", tt.query.Text, hits, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/pranotoism/football-go/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TeamRepository struct {
@@ -13,40 +16,75 @@ func NewTeamRepository(db *gorm.DB) *TeamRepository {
 	return &TeamRepository{db: db}
 }
 
-func (r *TeamRepository) Create(team *model.Team) error {
-	return r.db.Create(team).Error
+func (r *TeamRepository) Create(ctx context.Context, team *model.Team) error {
+	return conn(ctx, r.db).Create(team).Error
 }
 
-func (r *TeamRepository) FindAll(page, perPage int) ([]model.Team, int64, error) {
+func (r *TeamRepository) FindAll(ctx context.Context, q Query) (Page[model.Team], error) {
+	var teams []model.Team
+
+	query := conn(ctx, r.db).Model(&model.Team{}).Scopes(TeamSchema.filter(q))
+	total, err := count(query, q)
+	if err != nil {
+		return Page[model.Team]{}, err
+	}
+
+	if err := query.Scopes(TeamSchema.page(q)).Find(&teams).Error; err != nil {
+		return Page[model.Team]{}, err
+	}
+	return TeamSchema.Page(q, teams, total), nil
+}
+
+func (r *TeamRepository) FindByID(ctx context.Context, id uint) (*model.Team, error) {
+	var team model.Team
+	err := conn(ctx, r.db).Preload("Players").First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *TeamRepository) Update(ctx context.Context, team *model.Team) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Save(team).Error
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, team *model.Team) error {
+	return conn(ctx, r.db).Delete(team).Error
+}
+
+func (r *TeamRepository) FindDeleted(ctx context.Context, page, perPage int) ([]model.Team, int64, error) {
 	var teams []model.Team
 	var total int64
 
-	r.db.Model(&model.Team{}).Count(&total)
+	query := conn(ctx, r.db).Unscoped().Model(&model.Team{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
 	offset := (page - 1) * perPage
-	err := r.db.Offset(offset).Limit(perPage).Find(&teams).Error
+	err := query.Offset(offset).Limit(perPage).Order("deleted_at DESC").Find(&teams).Error
 	return teams, total, err
 }
 
-func (r *TeamRepository) FindByID(id uint) (*model.Team, error) {
+func (r *TeamRepository) FindDeletedByID(ctx context.Context, id uint) (*model.Team, error) {
 	var team model.Team
-	err := r.db.Preload("Players").First(&team, id).Error
+	err := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&team, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &team, nil
 }
 
-func (r *TeamRepository) Update(team *model.Team) error {
-	return r.db.Save(team).Error
+func (r *TeamRepository) Restore(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Model(&model.Team{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
 
-func (r *TeamRepository) Delete(team *model.Team) error {
-	return r.db.Delete(team).Error
+func (r *TeamRepository) Purge(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Unscoped().Where("id = ?", id).Delete(&model.Team{}).Error
 }
 
-func (r *TeamRepository) Exists(id uint) bool {
+func (r *TeamRepository) Exists(ctx context.Context, id uint) bool {
 	var count int64
-	r.db.Model(&model.Team{}).Where("id = ?", id).Count(&count)
+	conn(ctx, r.db).Model(&model.Team{}).Where("id = ?", id).Count(&count)
 	return count > 0
 }
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned by lookups that match no record.
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// ErrDuplicate is returned by writes that break a unique index.
+var ErrDuplicate = gorm.ErrDuplicatedKey
+
+// Transactor runs a unit of work. Repository calls made with the context
+// passed to fn take part in the same transaction, which is committed when
+// fn returns nil and rolled back otherwise. Nested calls join the
+// transaction that is already running.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type deletionTimeKey struct{}
+
+type GormTransactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *GormTransactor {
+	return &GormTransactor{db: db}
+}
+
+func (t *GormTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// WithDeletionTime makes every soft delete done with ctx stamp deletedAt, so
+// that records removed by one cascading delete can later be told apart from
+// records deleted earlier and restored together.
+func WithDeletionTime(ctx context.Context, deletedAt time.Time) context.Context {
+	return context.WithValue(ctx, deletionTimeKey{}, deletedAt)
+}
+
+// DeletionTime returns the timestamp set by WithDeletionTime.
+func DeletionTime(ctx context.Context) (time.Time, bool) {
+	deletedAt, ok := ctx.Value(deletionTimeKey{}).(time.Time)
+	return deletedAt, ok
+}
+
+// translate turns the error of a driver into one of gorm's, such as
+// ErrDuplicate.
+func translate(db *gorm.DB, err error) error {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		return translator.Translate(err)
+	}
+	return err
+}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		db = tx
+	}
+	db = db.WithContext(ctx)
+	if deletedAt, ok := DeletionTime(ctx); ok {
+		db = db.Session(&gorm.Session{NowFunc: func() time.Time { return deletedAt }})
+	}
+	return db
+}
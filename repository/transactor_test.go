@@ -0,0 +1,50 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pranotoism/football-go/database/databasetest"
+	"github.com/pranotoism/football-go/repository"
+)
+
+func TestGormTransactor(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	transactor := repository.NewTransactor(db)
+	teams := repository.NewTeamRepository(db)
+	matches := repository.NewMatchRepository(db)
+
+	home := createTeam(t, db, "Home")
+	away := createTeam(t, db, "Away")
+	match := createMatch(t, db, home, away)
+
+	errAbort := errors.New("abort")
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := matches.UpdateScore(ctx, match.ID, 1, 0); err != nil {
+			return err
+		}
+		// A nested unit of work joins the outer transaction.
+		return transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := teams.Delete(ctx, home); err != nil {
+				return err
+			}
+			return errAbort
+		})
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTx returned %v, want errAbort", err)
+	}
+
+	got, err := matches.FindByID(ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.HomeScore != nil {
+		t.Error("score update was not rolled back")
+	}
+	if !teams.Exists(ctx, home.ID) {
+		t.Error("team deletion in the nested unit of work was not rolled back")
+	}
+}
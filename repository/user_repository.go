@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/pranotoism/football-go/model"
 	"gorm.io/gorm"
 )
@@ -13,15 +15,41 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) Create(user *model.User) error {
-	return r.db.Create(user).Error
+func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
+	return conn(ctx, r.db).Create(user).Error
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, r.db).First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
-func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := conn(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
+
+func (r *UserRepository) FindByEmailVerificationToken(ctx context.Context, token string) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, r.db).Where("email_verification_token = ?", token).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *model.User) error {
+	return conn(ctx, r.db).Save(user).Error
+}
+
+func (r *UserRepository) Delete(ctx context.Context, user *model.User) error {
+	return conn(ctx, r.db).Delete(user).Error
+}
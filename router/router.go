@@ -2,20 +2,54 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/pranotoism/football-go/config"
 	"github.com/pranotoism/football-go/handler"
+	"github.com/pranotoism/football-go/metrics"
 	"github.com/pranotoism/football-go/middleware"
+	"github.com/pranotoism/football-go/model"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func Setup(
+	cfg *config.Config,
+	authMiddleware gin.HandlerFunc,
 	authHandler *handler.AuthHandler,
+	userHandler *handler.UserHandler,
 	teamHandler *handler.TeamHandler,
 	playerHandler *handler.PlayerHandler,
 	matchHandler *handler.MatchHandler,
 	reportHandler *handler.ReportHandler,
+	jwksHandler *handler.JWKSHandler,
+	auditHandler *handler.AuditHandler,
+	submissionHandler *handler.ResultSubmissionHandler,
+	trashHandler *handler.TrashHandler,
+	searchHandler *handler.SearchHandler,
+	importHandler *handler.ImportHandler,
+	exportHandler *handler.ExportHandler,
+	calendarHandler *handler.CalendarHandler,
+	healthHandler *handler.HealthHandler,
 ) *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(middleware.Metrics())
+	r.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(middleware.TraceFilter)))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.RequestLogger())
 	r.Use(middleware.ErrorHandler())
 
+	// Probes and metrics are registered before CORS and rate limiting so
+	// that orchestrator polling and scraping are never throttled.
+	r.GET("/healthz", healthHandler.Live)
+	r.GET("/readyz", healthHandler.Ready)
+	r.GET("/version", healthHandler.Version)
+	if cfg.Metrics.Enabled {
+		r.GET("/metrics", middleware.MetricsAuth(cfg.Metrics.Token), gin.WrapH(metrics.Handler()))
+	}
+
+	r.Use(middleware.CORS(cfg.CORS))
+	r.Use(middleware.RateLimit(cfg.RateLimit))
+
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	v1 := r.Group("/api/v1")
 	{
 		// Public routes
@@ -23,12 +57,27 @@ func Setup(
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/verify-email", userHandler.VerifyEmail)
 		}
 
+		// Fixture calendars are public, since calendar apps subscribe to
+		// them without credentials
+		v1.GET("/fixtures.ics", calendarHandler.Fixtures)
+		v1.GET("/teams/:id/fixtures.ics", calendarHandler.TeamFixtures)
+
 		// Protected routes
 		protected := v1.Group("")
-		protected.Use(middleware.AuthMiddleware())
+		protected.Use(authMiddleware)
+		officialOnly := middleware.RequireRole(model.RoleOfficial, model.RoleAdmin)
 		{
+			// Current user
+			me := protected.Group("/me")
+			{
+				me.GET("", userHandler.GetProfile)
+				me.PUT("", userHandler.UpdateProfile)
+				me.DELETE("", userHandler.DeleteAccount)
+			}
+
 			// Teams
 			teams := protected.Group("/teams")
 			{
@@ -57,15 +106,64 @@ func Setup(
 				matches.GET("/:id", matchHandler.FindByID)
 				matches.PUT("/:id", matchHandler.Update)
 				matches.DELETE("/:id", matchHandler.Delete)
-				matches.POST("/:id/result", matchHandler.ReportResult)
+				matches.POST("/:id/result", submissionHandler.Create)
+				matches.PUT("/:id/result", officialOnly, matchHandler.AmendResult)
+				matches.GET("/:id/revisions", matchHandler.FindRevisions)
+				matches.GET("/:id/submissions", submissionHandler.FindByMatch)
 				matches.GET("/:id/report", reportHandler.GetMatchReport)
 			}
 
+			// Result submissions
+			submissions := protected.Group("/submissions")
+			{
+				submissions.GET("", officialOnly, submissionHandler.FindByStatus)
+				submissions.GET("/:id", submissionHandler.FindByID)
+				submissions.PUT("/:id", submissionHandler.Update)
+				submissions.POST("/:id/submit", submissionHandler.Submit)
+				submissions.GET("/:id/diff", officialOnly, submissionHandler.Diff)
+				submissions.POST("/:id/approve", officialOnly, submissionHandler.Approve)
+				submissions.POST("/:id/reject", officialOnly, submissionHandler.Reject)
+			}
+
 			// Reports
 			reports := protected.Group("/reports")
 			{
 				reports.GET("/matches", reportHandler.GetAllMatchReports)
 			}
+
+			// Audit trail
+			protected.GET("/audit", middleware.RequireRole(model.RoleAdmin), auditHandler.FindAll)
+
+			// Search
+			protected.GET("/search", searchHandler.Search)
+
+			// Bulk import of teams, players and fixtures
+			protected.POST("/import/:kind", importHandler.Import)
+
+			// Exports as CSV, NDJSON or XLSX
+			export := protected.Group("/export")
+			{
+				export.GET("/matches", exportHandler.Matches)
+				export.GET("/players", exportHandler.Players)
+				export.GET("/goals", exportHandler.Goals)
+				export.GET("/reports", exportHandler.Reports)
+			}
+
+			// Admin: soft-deleted records
+			trash := protected.Group("/admin/trash")
+			trash.Use(middleware.RequireRole(model.RoleAdmin))
+			{
+				trash.GET("/teams", trashHandler.FindTeams)
+				trash.POST("/teams/:id/restore", trashHandler.RestoreTeam)
+				trash.DELETE("/teams/:id", trashHandler.PurgeTeam)
+				trash.GET("/players", trashHandler.FindPlayers)
+				trash.POST("/players/:id/restore", trashHandler.RestorePlayer)
+				trash.DELETE("/players/:id", trashHandler.PurgePlayer)
+				trash.GET("/matches", trashHandler.FindMatches)
+				trash.POST("/matches/:id/restore", trashHandler.RestoreMatch)
+				trash.DELETE("/matches/:id", trashHandler.PurgeMatch)
+				trash.GET("/goals", trashHandler.FindGoals)
+			}
 		}
 	}
 
@@ -0,0 +1,127 @@
+// Package server runs the HTTP server and shuts the application down in
+// order when it is told to stop.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pranotoism/football-go/config"
+)
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Server wraps an http.Server with the configured timeouts and limits. On
+// shutdown it first stops accepting connections and drains in-flight
+// requests, then runs the shutdown hooks in the order they were registered,
+// all within the configured shutdown timeout.
+type Server struct {
+	http            *http.Server
+	tls             config.TLSConfig
+	shutdownTimeout time.Duration
+	drainHooks      []func()
+	hooks           []hook
+	shuttingDown    atomic.Bool
+}
+
+func New(cfg config.ServerConfig, handler http.Handler) *Server {
+	return &Server{
+		http: &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Port),
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout.Duration,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout.Duration,
+			WriteTimeout:      cfg.WriteTimeout.Duration,
+			IdleTimeout:       cfg.IdleTimeout.Duration,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+		tls:             cfg.TLS,
+		shutdownTimeout: cfg.ShutdownTimeout.Duration,
+	}
+}
+
+// OnDrain registers fn to run as soon as shutdown starts, before in-flight
+// requests are drained, e.g. to start failing readiness probes.
+func (s *Server) OnDrain(fn func()) {
+	s.drainHooks = append(s.drainHooks, fn)
+}
+
+// OnShutdown registers fn to run after the HTTP server has drained. Hooks
+// run in registration order, so background workers should be registered
+// before the resources they use, such as the database.
+func (s *Server) OnShutdown(name string, fn func(ctx context.Context) error) {
+	s.hooks = append(s.hooks, hook{name: name, fn: fn})
+}
+
+// ShuttingDown reports whether shutdown has started.
+func (s *Server) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// Run listens on the configured port and serves until ctx is cancelled,
+// then shuts down.
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves on ln until ctx is cancelled or the server fails, then shuts
+// down. It returns the serve error, if any, joined with the shutdown
+// errors.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if s.tls.Enabled {
+			slog.Info("server listening", "addr", ln.Addr().String(), "tls", true)
+			serveErr <- s.http.ServeTLS(ln, s.tls.CertFile, s.tls.KeyFile)
+		} else {
+			slog.Info("server listening", "addr", ln.Addr().String(), "tls", false)
+			serveErr <- s.http.Serve(ln)
+		}
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining requests")
+	case err = <-serveErr:
+		err = fmt.Errorf("serve: %w", err)
+	}
+
+	return errors.Join(err, s.shutdown())
+}
+
+func (s *Server) shutdown() error {
+	s.shuttingDown.Store(true)
+	for _, fn := range s.drainHooks {
+		fn()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := s.http.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("drain HTTP requests: %w", err))
+	}
+	for _, h := range s.hooks {
+		if err := h.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shut down %s: %w", h.name, err))
+			continue
+		}
+		slog.Info("stopped", "component", h.name)
+	}
+	return errors.Join(errs...)
+}
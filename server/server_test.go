@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/config"
+)
+
+func testConfig() config.ServerConfig {
+	cfg := config.Default().Server
+	cfg.ShutdownTimeout = config.Duration{Duration: 5 * time.Second}
+	return cfg
+}
+
+func TestShutdownDrainsRequestsBeforeHooks(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "done")
+		record("request")
+	})
+
+	srv := New(testConfig(), handler)
+	srv.OnShutdown("workers", func(ctx context.Context) error { record("workers"); return nil })
+	srv.OnShutdown("database", func(ctx context.Context) error { record("database"); return nil })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	<-started
+	cancel()
+	for !srv.ShuttingDown() {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	if body := <-response; body != "done" {
+		t.Fatalf("in-flight request got %q, want it to complete", body)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Serve() = %v", err)
+	}
+
+	want := []string{"request", "workers", "database"}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+
+	if _, err := http.Get("http://" + ln.Addr().String()); err == nil {
+		t.Error("server still accepts requests after shutdown")
+	}
+}
+
+func TestServeReturnsListenErrorsAndStillShutsDown(t *testing.T) {
+	srv := New(testConfig(), http.NotFoundHandler())
+	closed := false
+	srv.OnShutdown("database", func(ctx context.Context) error { closed = true; return nil })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.Close()
+
+	if err := srv.Serve(context.Background(), ln); err == nil {
+		t.Error("expected an error from a closed listener")
+	}
+	if !closed {
+		t.Error("shutdown hooks did not run")
+	}
+}
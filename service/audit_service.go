@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+type AuditService struct {
+	auditRepo AuditRepository
+}
+
+func NewAuditService(auditRepo AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+func (s *AuditService) FindAll(ctx context.Context, query dto.AuditLogQuery, page, perPage int) ([]model.AuditLog, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.FindAll")
+	defer span.End()
+
+	filter := repository.AuditFilter{
+		Entity:   query.Entity,
+		EntityID: query.EntityID,
+		ActorID:  query.ActorID,
+		Action:   query.Action,
+	}
+
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return nil, 0, ErrInvalidTimestamp.Withf("from must be an RFC3339 timestamp")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return nil, 0, ErrInvalidTimestamp.Withf("to must be an RFC3339 timestamp")
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, 0, ErrInvalidTimeRange
+	}
+
+	return s.auditRepo.FindAll(ctx, filter, page, perPage)
+}
@@ -1,28 +1,33 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/metrics"
 	"github.com/pranotoism/football-go/model"
 	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
 	"github.com/pranotoism/football-go/util"
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 type AuthService struct {
-	userRepo *repository.UserRepository
+	userRepo UserRepository
 }
 
-func NewAuthService(userRepo *repository.UserRepository) *AuthService {
+func NewAuthService(userRepo UserRepository) *AuthService {
 	return &AuthService{userRepo: userRepo}
 }
 
-func (s *AuthService) Register(req dto.RegisterRequest) (*model.User, error) {
-	existing, _ := s.userRepo.FindByEmail(req.Email)
+func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	existing, _ := s.userRepo.FindByEmail(ctx, req.Email)
 	if existing != nil {
-		return nil, errors.New("email already registered")
+		return nil, ErrEmailTaken
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -34,32 +39,64 @@ func (s *AuthService) Register(req dto.RegisterRequest) (*model.User, error) {
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: string(hashedPassword),
+		Role:     model.RoleReporter,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (s *AuthService) Login(req dto.LoginRequest) (string, error) {
-	user, err := s.userRepo.FindByEmail(req.Email)
+func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", errors.New("invalid email or password")
+		if errors.Is(err, repository.ErrNotFound) {
+			metrics.LoginFailures.WithLabelValues(metrics.LoginUnknownEmail).Inc()
+			return "", ErrInvalidCredentials
 		}
 		return "", err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return "", errors.New("invalid email or password")
+		metrics.LoginFailures.WithLabelValues(metrics.LoginWrongPassword).Inc()
+		return "", ErrInvalidCredentials
 	}
 
-	token, err := util.GenerateToken(user.ID)
+	token, err := util.GenerateToken(user.ID, user.TokenVersion)
 	if err != nil {
 		return "", err
 	}
 
 	return token, nil
 }
+
+// Authenticate validates a bearer token and checks that it still belongs to
+// an active account whose tokens have not been revoked.
+func (s *AuthService) Authenticate(ctx context.Context, tokenString string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Authenticate")
+	defer span.End()
+
+	claims, err := util.ValidateToken(tokenString)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if user.TokenVersion != claims.TokenVersion {
+		return nil, ErrTokenRevoked
+	}
+
+	return user, nil
+}
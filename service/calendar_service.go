@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/ical"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+const (
+	// matchDuration is how long a fixture lasts in calendars: two halves,
+	// half time and stoppages.
+	matchDuration = 2 * time.Hour
+	// calendarYears bounds feeds, which are public: without dates they
+	// cover this many years either side of today, and a date range may
+	// span at most twice that.
+	calendarYears = 1
+	// maxCalendarEvents caps a feed however the range is chosen.
+	maxCalendarEvents = 5000
+)
+
+// sequenceEpoch is the zero of event sequence numbers, which count the
+// seconds from it to the last change of a match.
+var sequenceEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var errCalendarFull = errors.New("calendar is full")
+
+// CalendarService publishes fixtures as iCalendar feeds. Events carry
+// kickoffs in UTC so that every client shows them in its own zone; loc is
+// the zone clients default to and that date filters use.
+type CalendarService struct {
+	matchRepo MatchRepository
+	teamRepo  TeamRepository
+	loc       *time.Location
+}
+
+func NewCalendarService(matchRepo MatchRepository, teamRepo TeamRepository, loc *time.Location) *CalendarService {
+	return &CalendarService{matchRepo: matchRepo, teamRepo: teamRepo, loc: loc}
+}
+
+// Fixtures returns the fixtures that filter selects, such as the matches of
+// a season by date range.
+func (s *CalendarService) Fixtures(ctx context.Context, filter dto.MatchListQuery) (*ical.Calendar, error) {
+	ctx, span := tracing.Start(ctx, "CalendarService.Fixtures")
+	defer span.End()
+
+	return s.calendar(ctx, "Fixtures", filter, nil)
+}
+
+// TeamFixtures returns the fixtures of a team, naming the opponent in each.
+func (s *CalendarService) TeamFixtures(ctx context.Context, teamID uint, filter dto.MatchListQuery) (*ical.Calendar, error) {
+	ctx, span := tracing.Start(ctx, "CalendarService.TeamFixtures")
+	defer span.End()
+
+	team, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+	filter.TeamID = team.ID
+	return s.calendar(ctx, team.Name+" fixtures", filter, team)
+}
+
+func (s *CalendarService) calendar(ctx context.Context, name string, filter dto.MatchListQuery, team *model.Team) (*ical.Calendar, error) {
+	if filter.Sort == "" {
+		filter.Sort = "kickoff_at"
+	}
+	if err := s.window(&filter); err != nil {
+		return nil, err
+	}
+	q, err := matchQuery(filter, dto.Pagination{}, s.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &ical.Calendar{Name: name, TimeZone: s.loc.String(), Events: []ical.Event{}}
+	err = eachPage(ctx, repository.MatchSchema, q, s.matchRepo.FindAll, func(matches []model.Match) error {
+		for _, m := range matches {
+			if len(cal.Events) == maxCalendarEvents {
+				return errCalendarFull
+			}
+			cal.Events = append(cal.Events, fixtureEvent(m, team))
+		}
+		return nil
+	})
+	if errors.Is(err, errCalendarFull) {
+		return nil, ErrCalendarTooLarge.Withf("calendar has more than %d fixtures, narrow date_from and date_to", maxCalendarEvents)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
+
+// window fills in the dates filter leaves out, so that a feed covers
+// calendarYears either side of today or of the one date given, and rejects
+// ranges longer than twice that.
+func (s *CalendarService) window(filter *dto.MatchListQuery) error {
+	parse := func(param, value string) (time.Time, error) {
+		day, err := time.ParseInLocation(time.DateOnly, value, s.loc)
+		if err != nil {
+			return time.Time{}, ErrInvalidDate.Withf("%s %q is not a YYYY-MM-DD date", param, value)
+		}
+		return day, nil
+	}
+
+	var from, to time.Time
+	var err error
+	switch {
+	case filter.DateFrom == "" && filter.DateTo == "":
+		today := time.Now().In(s.loc)
+		from, to = today.AddDate(-calendarYears, 0, 0), today.AddDate(calendarYears, 0, 0)
+	case filter.DateTo == "":
+		if from, err = parse("date_from", filter.DateFrom); err != nil {
+			return err
+		}
+		to = from.AddDate(2*calendarYears, 0, 0)
+	case filter.DateFrom == "":
+		if to, err = parse("date_to", filter.DateTo); err != nil {
+			return err
+		}
+		from = to.AddDate(-2*calendarYears, 0, 0)
+	default:
+		if from, err = parse("date_from", filter.DateFrom); err != nil {
+			return err
+		}
+		if to, err = parse("date_to", filter.DateTo); err != nil {
+			return err
+		}
+		if to.After(from.AddDate(2*calendarYears, 0, 0)) {
+			return ErrInvalidRange.Withf("a calendar covers at most %d years", 2*calendarYears)
+		}
+	}
+	filter.DateFrom, filter.DateTo = from.Format(time.DateOnly), to.Format(time.DateOnly)
+	return nil
+}
+
+// fixtureEvent describes m from the point of view of team, if any.
+func fixtureEvent(m model.Match, team *model.Team) ical.Event {
+	home := teamInfo(m.HomeTeamID, m.HomeTeam).Name
+	away := teamInfo(m.AwayTeamID, m.AwayTeam).Name
+	event := ical.Event{
+		UID:      fmt.Sprintf("match-%d@football-go", m.ID),
+		Sequence: max(0, int(m.UpdatedAt.Sub(sequenceEpoch)/time.Second)),
+		Start:    m.KickoffAt,
+		End:      m.KickoffAt.Add(matchDuration),
+		Modified: m.UpdatedAt,
+		Summary:  home + " vs " + away,
+		Location: venue(m.HomeTeam),
+	}
+
+	var description []string
+	if team != nil {
+		switch team.ID {
+		case m.HomeTeamID:
+			description = append(description, "Opponent: "+away+" (home)")
+		case m.AwayTeamID:
+			description = append(description, "Opponent: "+home+" (away)")
+		}
+	}
+	if m.HomeScore != nil && m.AwayScore != nil {
+		event.Summary = fmt.Sprintf("%s %d-%d %s", home, *m.HomeScore, *m.AwayScore, away)
+		description = append(description, "Final score: "+event.Summary)
+	}
+	event.Description = strings.Join(description, "\n")
+	return event
+}
+
+// venue is where team plays its home matches: the address of its
+// headquarters.
+func venue(team *model.Team) string {
+	if team == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range []string{team.HQAddress, team.HQCity} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestTeamFixtures(t *testing.T) {
+	f := newFixture(t)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendars := service.NewCalendarService(f.store.Matches(), f.store.Teams(), jakarta)
+
+	home, err := f.teams.Create(f.ctx, dto.CreateTeamRequest{Name: "Persib", FoundedYear: 1933, HQAddress: "Jl. Sulanjana No. 17", HQCity: "Bandung"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	away := f.team(t, "Persija")
+	other := f.team(t, "Bali United")
+	scorer := f.player(t, home, 9)
+
+	played := f.match(t, home, away)
+	f.publish(t, played, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: scorer.ID, TeamID: home.ID, Minute: 30}}})
+	if _, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: "2026-05-17", MatchTime: "15:30", TimeZone: "Asia/Makassar", HomeTeamID: away.ID, AwayTeamID: home.ID}); err != nil {
+		t.Fatal(err)
+	}
+	f.match(t, away, other)
+
+	cal, err := calendars.TeamFixtures(f.ctx, home.ID, dto.MatchListQuery{DateFrom: "2026-01-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.Name != "Persib fixtures" || cal.TimeZone != "Asia/Jakarta" || len(cal.Events) != 2 {
+		t.Fatalf("calendar %q in %s has %d events, want Persib's 2 fixtures", cal.Name, cal.TimeZone, len(cal.Events))
+	}
+
+	first := cal.Events[0]
+	if first.UID != "match-1@football-go" {
+		t.Errorf("UID = %q, want one derived from the match ID", first.UID)
+	}
+	if want := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC); !first.Start.Equal(want) || first.End.Sub(first.Start) != 2*time.Hour {
+		t.Errorf("event runs %v to %v, want 2 hours from %v", first.Start.UTC(), first.End.UTC(), want)
+	}
+	if first.Summary != "Persib 1-0 Persija" || first.Location != "Jl. Sulanjana No. 17, Bandung" {
+		t.Errorf("event = %q at %q", first.Summary, first.Location)
+	}
+	if want := "Opponent: Persija (home)\nFinal score: Persib 1-0 Persija"; first.Description != want {
+		t.Errorf("description = %q, want %q", first.Description, want)
+	}
+
+	second := cal.Events[1]
+	if want := time.Date(2026, 5, 17, 7, 30, 0, 0, time.UTC); !second.Start.Equal(want) || second.Summary != "Persija vs Persib" || second.Description != "Opponent: Persija (away)" {
+		t.Errorf("fixture in another time zone = %+v, want Persija vs Persib from %v", second, want)
+	}
+
+	_, err = calendars.TeamFixtures(f.ctx, 99, dto.MatchListQuery{})
+	checkErr(t, err, service.ErrTeamNotFound.Message)
+}
+
+func TestFixturesOfSeason(t *testing.T) {
+	f := newFixture(t)
+	calendars := service.NewCalendarService(f.store.Matches(), f.store.Teams(), f.loc)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	f.match(t, home, away)
+	for _, kickoff := range [][2]string{{"2025-08-01", "15:30"}, {"2026-09-01", "15:30"}, {"2026-06-30", "23:00"}} {
+		if _, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: kickoff[0], MatchTime: kickoff[1], HomeTeamID: away.ID, AwayTeamID: home.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cal, err := calendars.Fixtures(f.ctx, dto.MatchListQuery{DateFrom: "2025-07-01", DateTo: "2026-06-30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uids []string
+	for _, e := range cal.Events {
+		uids = append(uids, e.UID)
+	}
+	if !slices.Equal(uids, []string{"match-2@football-go", "match-1@football-go", "match-4@football-go"}) {
+		t.Errorf("season events = %v, want matches 2, 1 and 4 in kickoff order", uids)
+	}
+	if want := time.Date(2025, 8, 1, 8, 30, 0, 0, time.UTC); !cal.Events[0].Start.Equal(want) {
+		t.Errorf("kickoff = %v, want 15:30 in Jakarta, %v", cal.Events[0].Start, want)
+	}
+}
+
+func TestFixturesWindow(t *testing.T) {
+	f := newFixture(t)
+	calendars := service.NewCalendarService(f.store.Matches(), f.store.Teams(), f.loc)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+
+	today := time.Now().In(f.loc)
+	schedule := func(day time.Time) *model.Match {
+		t.Helper()
+		match, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: day.Format(time.DateOnly), MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: away.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return match
+	}
+	upcoming := schedule(today.AddDate(0, 0, 10))
+	old := schedule(today.AddDate(-3, 0, 0))
+
+	tests := []struct {
+		name    string
+		filter  dto.MatchListQuery
+		want    []uint
+		wantErr error
+	}{
+		{"a year either side of today", dto.MatchListQuery{}, []uint{upcoming.ID}, nil},
+		{"two years from date_from", dto.MatchListQuery{DateFrom: today.AddDate(-3, 0, -1).Format(time.DateOnly)}, []uint{old.ID}, nil},
+		{"two years up to date_to", dto.MatchListQuery{DateTo: today.AddDate(-1, 0, 0).Format(time.DateOnly)}, []uint{old.ID}, nil},
+		{"range too long", dto.MatchListQuery{DateFrom: today.AddDate(-3, 0, -1).Format(time.DateOnly), DateTo: today.Format(time.DateOnly)}, nil, service.ErrInvalidRange},
+		{"bad date", dto.MatchListQuery{DateTo: "soon"}, nil, service.ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := calendars.Fixtures(f.ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Fixtures() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var ids []uint
+			for _, e := range cal.Events {
+				var id uint
+				fmt.Sscanf(e.UID, "match-%d@", &id)
+				ids = append(ids, id)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("events of matches %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixtureSequenceGrowsWithChanges(t *testing.T) {
+	f := newFixture(t)
+	calendars := service.NewCalendarService(f.store.Matches(), f.store.Teams(), f.loc)
+	match := f.match(t, f.team(t, "Home"), f.team(t, "Away"))
+	filter := dto.MatchListQuery{DateFrom: "2026-01-01"}
+
+	before, err := calendars.Fixtures(f.ctx, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := f.matches.Update(f.ctx, match.ID, dto.UpdateMatchRequest{MatchTime: "20:00"}); err != nil {
+		t.Fatal(err)
+	}
+	after, err := calendars.Fixtures(f.ctx, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if before.Events[0].Sequence <= 0 || after.Events[0].Sequence <= before.Events[0].Sequence {
+		t.Errorf("sequence went from %d to %d on a reschedule, want it to grow", before.Events[0].Sequence, after.Events[0].Sequence)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import "fmt"
+
+// Kind classifies an Error. The HTTP layer maps each kind to a status code.
+type Kind int
+
+const (
+	KindValidation Kind = iota + 1
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+)
+
+// Error is an error the client can act on. Code is a stable,
+// machine-readable identifier; Message is meant for humans and may include
+// details of the failing request.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError describes why one field of a request was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so a copy made with Withf still matches the
+// error it was made from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Withf returns a copy of e with a message formatted from format and args.
+func (e *Error) Withf(format string, args ...any) *Error {
+	copy := *e
+	copy.Message = fmt.Sprintf(format, args...)
+	return &copy
+}
+
+// ValidationError reports a malformed request, optionally with the fields
+// that caused it.
+func ValidationError(code, message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message, Fields: fields}
+}
+
+var (
+	ErrInvalidCredentials = newError(KindUnauthorized, "invalid_credentials", "invalid email or password")
+	ErrInvalidToken       = newError(KindUnauthorized, "invalid_token", "invalid or expired token")
+	ErrTokenRevoked       = newError(KindUnauthorized, "token_revoked", "token has been revoked")
+
+	ErrNotReporter = newError(KindForbidden, "not_reporter", "only the reporter can change this submission")
+
+	ErrUserNotFound          = newError(KindNotFound, "user_not_found", "user not found")
+	ErrTeamNotFound          = newError(KindNotFound, "team_not_found", "team not found")
+	ErrHomeTeamNotFound      = newError(KindNotFound, "home_team_not_found", "home team not found")
+	ErrAwayTeamNotFound      = newError(KindNotFound, "away_team_not_found", "away team not found")
+	ErrPlayerNotFound        = newError(KindNotFound, "player_not_found", "player not found")
+	ErrMatchNotFound         = newError(KindNotFound, "match_not_found", "match not found")
+	ErrSubmissionNotFound    = newError(KindNotFound, "submission_not_found", "result submission not found")
+	ErrDeletedTeamNotFound   = newError(KindNotFound, "deleted_team_not_found", "deleted team not found")
+	ErrDeletedPlayerNotFound = newError(KindNotFound, "deleted_player_not_found", "deleted player not found")
+	ErrDeletedMatchNotFound  = newError(KindNotFound, "deleted_match_not_found", "deleted match not found")
+
+	ErrSameTeams                = newError(KindValidation, "same_teams", "home team and away team cannot be the same")
+	ErrGoalCountMismatch        = newError(KindValidation, "goal_count_mismatch", "goal count does not match the score")
+	ErrGoalTeamMismatch         = newError(KindValidation, "goal_team_mismatch", "goal team does not belong to either team in this match")
+	ErrInvalidTimestamp         = newError(KindValidation, "invalid_timestamp", "timestamp must be RFC3339")
+	ErrInvalidTimeRange         = newError(KindValidation, "invalid_time_range", "to must not be before from")
+	ErrInvalidRange             = newError(KindValidation, "invalid_range", "the end of a range must not be before its start")
+	ErrCalendarTooLarge         = newError(KindValidation, "calendar_too_large", "calendar has too many fixtures")
+	ErrInvalidDate              = newError(KindValidation, "invalid_date", "date must be YYYY-MM-DD")
+	ErrInvalidKickoffTime       = newError(KindValidation, "invalid_kickoff_time", "kickoff time must be HH:MM or HH:MM:SS")
+	ErrInvalidTimeZone          = newError(KindValidation, "invalid_time_zone", "time zone must be an IANA name such as Asia/Jakarta")
+	ErrInvalidSort              = newError(KindValidation, "invalid_sort", "invalid sort order")
+	ErrInvalidCursor            = newError(KindValidation, "invalid_cursor", "invalid cursor")
+	ErrSearchTooShort           = newError(KindValidation, "search_too_short", "search text must be at least 2 characters")
+	ErrInvalidSearchType        = newError(KindValidation, "invalid_search_type", "invalid search type")
+	ErrInvalidImportKind        = newError(KindValidation, "invalid_import_kind", "only teams, players and fixtures can be imported")
+	ErrInvalidImportFormat      = newError(KindValidation, "invalid_import_format", "import format must be csv or json")
+	ErrMalformedImport          = newError(KindValidation, "malformed_import", "import could not be read")
+	ErrEmptyImport              = newError(KindValidation, "empty_import", "import has no rows")
+	ErrImportTooLarge           = newError(KindValidation, "import_too_large", "import has too many rows")
+	ErrImportFailed             = newError(KindValidation, "import_failed", "import has invalid rows, nothing was imported")
+	ErrInvalidExportFormat      = newError(KindValidation, "invalid_export_format", "export format must be csv, ndjson or xlsx")
+	ErrInvalidReportFormat      = newError(KindValidation, "invalid_report_format", "report format must be json, html or pdf")
+	ErrRejectionNoteRequired    = newError(KindValidation, "note_required", "a note is required when rejecting a submission")
+	ErrInvalidVerificationToken = newError(KindValidation, "invalid_verification_token", "invalid verification token")
+	ErrVerificationTokenExpired = newError(KindValidation, "verification_token_expired", "verification token has expired")
+
+	ErrEmailTaken            = newError(KindConflict, "email_taken", "email already registered")
+	ErrJerseyNumberTaken     = newError(KindConflict, "jersey_number_taken", "jersey number already taken in this team")
+	ErrPlayerHasGoals        = newError(KindConflict, "player_has_goals", "player has scored goals in recorded matches and cannot be deleted")
+	ErrTeamHasMatches        = newError(KindConflict, "team_has_matches", "team is referenced by matches, delete them first or pass cascade=true")
+	ErrTeamReferenced        = newError(KindConflict, "team_referenced", "team is still referenced by matches, purge those matches first")
+	ErrPlayerReferenced      = newError(KindConflict, "player_referenced", "player is still referenced by goals, purge those matches first")
+	ErrSubmissionPending     = newError(KindConflict, "submission_pending", "match already has a pending result submission")
+	ErrResultAlreadyReported = newError(KindConflict, "result_already_reported", "match result already reported")
+
+	ErrResultNotReported = newError(KindPreconditionFailed, "result_not_reported", "match result has not been reported yet")
+	ErrSubmissionState   = newError(KindPreconditionFailed, "invalid_submission_state", "the submission is not in a state that allows this")
+	ErrTeamDeleted       = newError(KindPreconditionFailed, "team_deleted", "a team of this record is deleted, restore the team first")
+	ErrWithinRetention   = newError(KindPreconditionFailed, "within_retention_period", "record is within its retention period")
+)
@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestErrorsMatchByCode(t *testing.T) {
+	err := service.ErrSubmissionState.Withf("a %s submission cannot be approved", "draft")
+
+	if !errors.Is(err, service.ErrSubmissionState) {
+		t.Fatal("expected a formatted copy to match its sentinel")
+	}
+	if errors.Is(err, service.ErrResultNotReported) {
+		t.Fatal("expected errors with different codes not to match")
+	}
+	if err.Error() != "a draft submission cannot be approved" || service.ErrSubmissionState.Message == err.Message {
+		t.Fatalf("expected only the copy to carry the new message, got %q", err.Error())
+	}
+}
+
+func TestServiceReturnsTypedErrors(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.players.FindByID(f.ctx, 999)
+	if !errors.Is(err, service.ErrPlayerNotFound) {
+		t.Fatalf("expected service.ErrPlayerNotFound, got %v", err)
+	}
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) || serviceErr.Kind != service.KindNotFound {
+		t.Fatalf("expected a not-found kind, got %v", err)
+	}
+}
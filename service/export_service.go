@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/export"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+// ExportService writes matches, players, goals and match reports as CSV,
+// NDJSON or XLSX. Filters and sort orders are those of the list endpoints.
+// Rows are read in batches and written as they arrive, so an error after
+// the first row leaves the output cut short; errors in the filters or the
+// format are reported before anything is written. Kickoffs are written in
+// UTC, and match dates and times in the time zone of each match; date
+// filters are days in loc.
+type ExportService struct {
+	teamRepo   TeamRepository
+	playerRepo PlayerRepository
+	matchRepo  MatchRepository
+	goalRepo   GoalRepository
+	reports    *ReportService
+	loc        *time.Location
+}
+
+func NewExportService(teamRepo TeamRepository, playerRepo PlayerRepository, matchRepo MatchRepository, goalRepo GoalRepository, reports *ReportService, loc *time.Location) *ExportService {
+	return &ExportService{teamRepo: teamRepo, playerRepo: playerRepo, matchRepo: matchRepo, goalRepo: goalRepo, reports: reports, loc: loc}
+}
+
+var matchColumns = []string{
+	"id", "kickoff_at", "time_zone", "match_date", "match_time", "competition", "home_team_id", "home_team", "away_team_id", "away_team", "home_score", "away_score",
+}
+
+func (s *ExportService) Matches(ctx context.Context, filter dto.MatchListQuery, format string, out io.Writer) error {
+	ctx, span := tracing.Start(ctx, "ExportService.Matches")
+	defer span.End()
+
+	q, err := matchQuery(filter, dto.Pagination{}, s.loc)
+	if err != nil {
+		return err
+	}
+	return exportRows(ctx, format, out, matchColumns, repository.MatchSchema, q, s.matchRepo.FindAll, func(m model.Match) []any {
+		return []any{
+			m.ID, m.KickoffAt, m.TimeZone, m.MatchDate(), m.MatchTime(), m.Competition,
+			m.HomeTeamID, teamInfo(m.HomeTeamID, m.HomeTeam).Name,
+			m.AwayTeamID, teamInfo(m.AwayTeamID, m.AwayTeam).Name,
+			m.HomeScore, m.AwayScore,
+		}
+	})
+}
+
+var playerColumns = []string{
+	"id", "team_id", "team", "name", "position", "jersey_number", "height_cm", "weight_kg",
+}
+
+func (s *ExportService) Players(ctx context.Context, filter dto.PlayerExportQuery, format string, out io.Writer) error {
+	ctx, span := tracing.Start(ctx, "ExportService.Players")
+	defer span.End()
+
+	q, err := playerQuery(dto.PlayerListQuery{Name: filter.Name, Position: filter.Position, Sort: filter.Sort}, dto.Pagination{})
+	if err != nil {
+		return err
+	}
+	if filter.TeamID != 0 {
+		if !s.teamRepo.Exists(ctx, filter.TeamID) {
+			return ErrTeamNotFound
+		}
+		q.Where(repository.OpEq, filter.TeamID, "team_id")
+	}
+	return exportRows(ctx, format, out, playerColumns, repository.PlayerSchema, q, s.playerRepo.FindAll, func(p model.Player) []any {
+		return []any{
+			p.ID, p.TeamID, teamInfo(p.TeamID, p.Team).Name, p.Name, p.Position, p.JerseyNumber, p.HeightCM, p.WeightKG,
+		}
+	})
+}
+
+var goalColumns = []string{
+	"id", "match_id", "match_date", "team_id", "team", "player_id", "player", "minute",
+}
+
+func (s *ExportService) Goals(ctx context.Context, filter dto.GoalExportQuery, format string, out io.Writer) error {
+	ctx, span := tracing.Start(ctx, "ExportService.Goals")
+	defer span.End()
+
+	q, err := newQuery(repository.GoalSchema, filter.Sort, dto.Pagination{})
+	if err != nil {
+		return err
+	}
+	if filter.MatchID != 0 {
+		q.Where(repository.OpEq, filter.MatchID, "match_id")
+	}
+	if filter.TeamID != 0 {
+		q.Where(repository.OpEq, filter.TeamID, "team_id")
+	}
+	if filter.PlayerID != 0 {
+		q.Where(repository.OpEq, filter.PlayerID, "player_id")
+	}
+	return exportRows(ctx, format, out, goalColumns, repository.GoalSchema, q, s.goalRepo.FindAll, func(g model.Goal) []any {
+		var matchDate any
+		if g.Match != nil {
+			matchDate = g.Match.MatchDate()
+		}
+		player := unknownName
+		if g.Player != nil {
+			player = g.Player.Name
+		}
+		return []any{
+			g.ID, g.MatchID, matchDate, g.TeamID, teamInfo(g.TeamID, g.Team).Name, g.PlayerID, player, g.Minute,
+		}
+	})
+}
+
+var reportColumns = []string{
+	"match_id", "kickoff_at", "time_zone", "match_date", "match_time", "competition", "home_team_id", "home_team", "away_team_id", "away_team",
+	"home_score", "away_score", "status", "goals", "top_scorer", "top_scorer_goals",
+	"cumulative_home_wins", "cumulative_away_wins",
+}
+
+// Reports writes a row per played match. The goals column lists the goals
+// as "player (team) minute'", separated by semicolons.
+func (s *ExportService) Reports(ctx context.Context, filter dto.MatchListQuery, format string, out io.Writer) error {
+	ctx, span := tracing.Start(ctx, "ExportService.Reports")
+	defer span.End()
+
+	q, err := matchQuery(filter, dto.Pagination{}, s.loc)
+	if err != nil {
+		return err
+	}
+	return exportRows(ctx, format, out, reportColumns, repository.MatchSchema, q, s.matchRepo.FindPlayedMatches, func(m model.Match) []any {
+		report := s.reports.buildReport(ctx, &m)
+
+		goals := make([]string, len(report.Goals))
+		for i, g := range report.Goals {
+			goals[i] = fmt.Sprintf("%s (%s) %d'", g.PlayerName, g.TeamName, g.Minute)
+		}
+		var topScorer, topScorerGoals any
+		if report.TopScorer != nil {
+			topScorer, topScorerGoals = report.TopScorer.PlayerName, report.TopScorer.Goals
+		}
+		return []any{
+			report.MatchID, report.KickoffAt, report.TimeZone, report.MatchDate, report.MatchTime, report.Competition,
+			report.HomeTeam.ID, report.HomeTeam.Name, report.AwayTeam.ID, report.AwayTeam.Name,
+			report.HomeScore, report.AwayScore, report.Status, strings.Join(goals, "; "),
+			topScorer, topScorerGoals, report.CumulativeHomeWins, report.CumulativeAwayWins,
+		}
+	})
+}
+
+// flusher is implemented by outputs that buffer, such as an HTTP response.
+type flusher interface {
+	Flush() error
+}
+
+// exportRows writes a row for each item matched by q, flushing out after
+// every page.
+func exportRows[T any](ctx context.Context, format string, out io.Writer, columns []string, schema repository.Schema[T], q repository.Query, find func(context.Context, repository.Query) (repository.Page[T], error), row func(T) []any) error {
+	w, err := export.NewWriter(format, out, columns)
+	if errors.Is(err, export.ErrUnsupportedFormat) {
+		return ErrInvalidExportFormat
+	}
+	if err != nil {
+		return err
+	}
+
+	err = eachPage(ctx, schema, q, find, func(items []T) error {
+		for _, item := range items {
+			if err := w.Write(row(item)); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if f, ok := out.(flusher); ok {
+			return f.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/service"
+)
+
+func (f *fixture) exporter() *service.ExportService {
+	return service.NewExportService(f.store.Teams(), f.store.Players(), f.store.Matches(), f.store.Goals(), service.NewReportService(f.store.Matches()), f.loc)
+}
+
+func readCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestExportPlayersAcrossBatches(t *testing.T) {
+	f := newFixture(t)
+	var teams []*model.Team
+	for i := range 6 {
+		team := f.team(t, "Team "+strconv.Itoa(i))
+		teams = append(teams, team)
+		for jersey := 1; jersey <= 99; jersey++ {
+			player := model.Player{TeamID: team.ID, Name: "Player", Position: "gelandang", JerseyNumber: jersey}
+			if err := f.store.Players().Create(f.ctx, &player); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.exporter().Players(f.ctx, dto.PlayerExportQuery{}, "csv", &buf); err != nil {
+		t.Fatal(err)
+	}
+	records := readCSV(t, buf.Bytes())
+	if len(records) != 1+6*99 {
+		t.Fatalf("exported %d records, want a header and %d players", len(records), 6*99)
+	}
+	for i, record := range records[1:] {
+		if record[0] != strconv.Itoa(i+1) {
+			t.Fatalf("record %d has id %s, want players in id order without gaps", i+1, record[0])
+		}
+	}
+
+	buf.Reset()
+	filter := dto.PlayerExportQuery{TeamID: teams[2].ID, Sort: "-jersey_number"}
+	if err := f.exporter().Players(f.ctx, filter, "csv", &buf); err != nil {
+		t.Fatal(err)
+	}
+	records = readCSV(t, buf.Bytes())
+	if len(records) != 100 || records[1][2] != "Team 2" || records[1][5] != "99" {
+		t.Errorf("team export starts %v and has %d records, want Team 2's 99 players from jersey 99", records[:2], len(records))
+	}
+}
+
+func TestExportReportsAndGoals(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "=Home")
+	away := f.team(t, "Away")
+	scorer := f.player(t, home, 9)
+	played := f.match(t, home, away)
+	f.match(t, away, home)
+	f.publish(t, played, dto.ReportResultRequest{HomeScore: 2, Goals: []dto.GoalInput{
+		{PlayerID: scorer.ID, TeamID: home.ID, Minute: 10},
+		{PlayerID: scorer.ID, TeamID: home.ID, Minute: 75},
+	}})
+
+	var buf bytes.Buffer
+	if err := f.exporter().Reports(f.ctx, dto.MatchListQuery{TeamID: home.ID}, "csv", &buf); err != nil {
+		t.Fatal(err)
+	}
+	records := readCSV(t, buf.Bytes())
+	if len(records) != 2 {
+		t.Fatalf("exported %d records, want a header and the played match", len(records))
+	}
+	got := strings.Join(records[1][:16], "|")
+	want := "1|2026-05-10T12:00:00Z|Asia/Jakarta|2026-05-10|19:00||1|'=Home|2|Away|2|0|Home Win|Player (=Home) 10'; Player (=Home) 75'|Player|2"
+	if got != want {
+		t.Errorf("report = %s, want %s", got, want)
+	}
+
+	buf.Reset()
+	if err := f.exporter().Goals(f.ctx, dto.GoalExportQuery{MatchID: played.ID, Sort: "-minute"}, "ndjson", &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"minute":75`) || !strings.Contains(lines[0], `"team":"=Home"`) {
+		t.Errorf("goals = %q, want the goal in minute 75 first", lines)
+	}
+}
+
+func TestExportErrorsBeforeWriting(t *testing.T) {
+	f := newFixture(t)
+	f.team(t, "Home")
+
+	tests := []struct {
+		name   string
+		export func(*bytes.Buffer) error
+		want   *service.Error
+	}{
+		{"format", func(buf *bytes.Buffer) error {
+			return f.exporter().Matches(f.ctx, dto.MatchListQuery{}, "pdf", buf)
+		}, service.ErrInvalidExportFormat},
+		{"sort", func(buf *bytes.Buffer) error {
+			return f.exporter().Goals(f.ctx, dto.GoalExportQuery{Sort: "player_id"}, "csv", buf)
+		}, service.ErrInvalidSort},
+		{"range", func(buf *bytes.Buffer) error {
+			return f.exporter().Reports(f.ctx, dto.MatchListQuery{DateFrom: "2026-02-01", DateTo: "2026-01-01"}, "csv", buf)
+		}, service.ErrInvalidRange},
+		{"team", func(buf *bytes.Buffer) error {
+			return f.exporter().Players(f.ctx, dto.PlayerExportQuery{TeamID: 99}, "csv", buf)
+		}, service.ErrTeamNotFound},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		err := tt.export(&buf)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("%s: wrote %q before failing", tt.name, buf.String())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/version"
+)
+
+// healthCheckTimeout bounds each readiness check, so a hanging dependency
+// fails the probe instead of stalling it.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck reports whether a dependency the application needs to serve
+// requests is usable.
+type HealthCheck func(ctx context.Context) error
+
+// SchemaVersioner reports the applied and expected database schema
+// versions. It is implemented by database.Migrator.
+type SchemaVersioner interface {
+	Version(ctx context.Context) (int64, error)
+	Latest() int64
+}
+
+type namedCheck struct {
+	name  string
+	check HealthCheck
+}
+
+// HealthService answers liveness, readiness and build info probes. The
+// database schema is always checked for readiness; other dependencies, such
+// as the database connection or queue backends, are added with AddCheck.
+type HealthService struct {
+	schema   SchemaVersioner
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+func NewHealthService(schema SchemaVersioner) *HealthService {
+	s := &HealthService{schema: schema}
+	s.AddCheck("migrations", s.checkSchema)
+	return s
+}
+
+// AddCheck adds a readiness check. It must be called before the server
+// starts.
+func (s *HealthService) AddCheck(name string, check HealthCheck) {
+	s.checks = append(s.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes every later readiness check fail, so that load balancers stop
+// routing new requests while in-flight ones finish.
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready runs every check concurrently and reports whether all passed along
+// with the result of each.
+func (s *HealthService) Ready(ctx context.Context) (bool, dto.ReadinessResponse) {
+	resp := dto.ReadinessResponse{Checks: make(map[string]string, len(s.checks))}
+	if s.draining.Load() {
+		resp.Checks["shutdown"] = "server is shutting down"
+		return false, resp
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ready := true
+	for _, c := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			result := "ok"
+			if err := c.check(checkCtx); err != nil {
+				result = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[c.name] = result
+			if result != "ok" {
+				ready = false
+			}
+		}()
+	}
+	wg.Wait()
+	return ready, resp
+}
+
+// Version describes the running build and the schema version it runs on.
+func (s *HealthService) Version(ctx context.Context) dto.VersionResponse {
+	resp := dto.VersionResponse{
+		Commit:                version.Commit,
+		BuildTime:             version.BuildTime,
+		GoVersion:             version.GoVersion,
+		ExpectedSchemaVersion: s.schema.Latest(),
+	}
+	if v, err := s.schema.Version(ctx); err == nil {
+		resp.SchemaVersion = &v
+	}
+	return resp
+}
+
+func (s *HealthService) checkSchema(ctx context.Context) error {
+	current, err := s.schema.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if latest := s.schema.Latest(); current != latest {
+		return fmt.Errorf("schema is at version %d, expected %d", current, latest)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pranotoism/football-go/service"
+)
+
+type fakeSchema struct {
+	version, latest int64
+}
+
+func (f fakeSchema) Version(ctx context.Context) (int64, error) { return f.version, nil }
+func (f fakeSchema) Latest() int64                              { return f.latest }
+
+func TestHealthReadiness(t *testing.T) {
+	ctx := context.Background()
+
+	health := service.NewHealthService(fakeSchema{version: 5, latest: 5})
+	dbErr := error(nil)
+	health.AddCheck("database", func(ctx context.Context) error { return dbErr })
+
+	if ready, resp := health.Ready(ctx); !ready {
+		t.Fatalf("not ready: %v", resp.Checks)
+	}
+
+	dbErr = errors.New("connection refused")
+	ready, resp := health.Ready(ctx)
+	if ready || resp.Checks["database"] != "connection refused" || resp.Checks["migrations"] != "ok" {
+		t.Errorf("Ready() = %v, %v; want the database check to fail", ready, resp.Checks)
+	}
+
+	dbErr = nil
+	health.Drain()
+	if ready, resp := health.Ready(ctx); ready {
+		t.Errorf("ready while draining: %v", resp.Checks)
+	}
+}
+
+func TestHealthReadinessRequiresLatestSchema(t *testing.T) {
+	health := service.NewHealthService(fakeSchema{version: 4, latest: 5})
+
+	ready, resp := health.Ready(context.Background())
+	if ready || resp.Checks["migrations"] != "schema is at version 4, expected 5" {
+		t.Errorf("Ready() = %v, %v; want the migrations check to fail", ready, resp.Checks)
+	}
+
+	v := health.Version(context.Background())
+	if v.SchemaVersion == nil || *v.SchemaVersion != 4 || v.ExpectedSchemaVersion != 5 {
+		t.Errorf("Version() = %+v", v)
+	}
+}
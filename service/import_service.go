@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+const (
+	ImportTeams    = "teams"
+	ImportPlayers  = "players"
+	ImportFixtures = "fixtures"
+
+	ImportCSV  = "csv"
+	ImportJSON = "json"
+)
+
+const maxImportRows = 5000
+
+// importErrorFields names the field to blame for the service errors that
+// concern a single field of a row.
+var importErrorFields = map[string]string{
+	ErrTeamNotFound.Code:      "team_id",
+	ErrJerseyNumberTaken.Code: "jersey_number",
+	ErrHomeTeamNotFound.Code:  "home_team_id",
+	ErrAwayTeamNotFound.Code:  "away_team_id",
+	ErrSameTeams.Code:         "away_team_id",
+}
+
+// errImportRollback rolls back a dry run or an import with invalid rows.
+var errImportRollback = errors.New("import rolled back")
+
+// ImportResult reports on an import. Errors refer to rows by their index,
+// e.g. "rows[0].name" for the first row.
+type ImportResult struct {
+	Kind     string       `json:"kind"`
+	DryRun   bool         `json:"dry_run"`
+	Rows     int          `json:"rows"`
+	Imported int          `json:"imported"`
+	IDs      []uint       `json:"ids,omitempty"`
+	Errors   []FieldError `json:"errors"`
+}
+
+// ImportService creates teams, players and fixtures in bulk through the
+// same services as the single-record endpoints, so that every row passes
+// the same checks.
+type ImportService struct {
+	tx      repository.Transactor
+	teams   *TeamService
+	players *PlayerService
+	matches *MatchService
+}
+
+func NewImportService(tx repository.Transactor, teams *TeamService, players *PlayerService, matches *MatchService) *ImportService {
+	return &ImportService{tx: tx, teams: teams, players: players, matches: matches}
+}
+
+// Import reads rows of kind in format from r and creates them in one
+// transaction. It checks every row before giving up, and imports nothing
+// unless all rows are valid. A dry run checks the rows the same way and
+// then rolls back.
+func (s *ImportService) Import(ctx context.Context, kind, format string, r io.Reader, dryRun bool) (*ImportResult, error) {
+	ctx, span := tracing.Start(ctx, "ImportService.Import")
+	defer span.End()
+
+	switch kind {
+	case ImportTeams:
+		return importRows(ctx, s.tx, kind, format, r, dryRun, func(ctx context.Context, row dto.CreateTeamRequest) (uint, error) {
+			team, err := s.teams.Create(ctx, row)
+			if err != nil {
+				return 0, err
+			}
+			return team.ID, nil
+		})
+	case ImportPlayers:
+		return importRows(ctx, s.tx, kind, format, r, dryRun, func(ctx context.Context, row dto.ImportPlayerRow) (uint, error) {
+			player, err := s.players.Create(ctx, row.TeamID, dto.CreatePlayerRequest{
+				Name:         row.Name,
+				HeightCM:     row.HeightCM,
+				WeightKG:     row.WeightKG,
+				Position:     row.Position,
+				JerseyNumber: row.JerseyNumber,
+			})
+			if err != nil {
+				return 0, err
+			}
+			return player.ID, nil
+		})
+	case ImportFixtures:
+		return importRows(ctx, s.tx, kind, format, r, dryRun, func(ctx context.Context, row dto.CreateMatchRequest) (uint, error) {
+			match, err := s.matches.Create(ctx, row)
+			if err != nil {
+				return 0, err
+			}
+			return match.ID, nil
+		})
+	default:
+		return nil, ErrInvalidImportKind.Withf("cannot import %q, only teams, players and fixtures", kind)
+	}
+}
+
+func importRows[T any](ctx context.Context, tx repository.Transactor, kind, format string, r io.Reader, dryRun bool, create func(context.Context, T) (uint, error)) (*ImportResult, error) {
+	var (
+		rows    []T
+		rowErrs [][]FieldError
+		err     error
+	)
+	switch format {
+	case ImportCSV:
+		rows, rowErrs, err = decodeCSV[T](r)
+	case ImportJSON:
+		rows, rowErrs, err = decodeJSON[T](r)
+	default:
+		return nil, ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	invalid := 0
+	for i := range rows {
+		if len(rowErrs[i]) == 0 {
+			var validationErrs validator.ValidationErrors
+			if err := validate.Struct(rows[i]); errors.As(err, &validationErrs) {
+				rowErrs[i] = FieldErrors(validationErrs)
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if len(rowErrs[i]) > 0 {
+			invalid++
+		}
+	}
+
+	var ids []uint
+
+	// Valid rows are created even when others are invalid, so that
+	// conflicts between rows, such as two players with the same jersey
+	// number, are reported too.
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		for i, row := range rows {
+			if len(rowErrs[i]) > 0 {
+				continue
+			}
+			id, err := create(ctx, row)
+			var serviceErr *Error
+			if errors.As(err, &serviceErr) {
+				rowErrs[i] = []FieldError{{Field: importErrorFields[serviceErr.Code], Code: serviceErr.Code, Message: serviceErr.Message}}
+				invalid++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if dryRun || invalid > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, err
+	}
+
+	result := &ImportResult{Kind: kind, DryRun: dryRun, Rows: len(rows), Errors: []FieldError{}}
+	for i, errs := range rowErrs {
+		for _, fe := range errs {
+			fe.Field = rowField(i, fe.Field)
+			result.Errors = append(result.Errors, fe)
+		}
+	}
+	switch {
+	case dryRun:
+	case invalid > 0:
+		return nil, ValidationError(ErrImportFailed.Code, ErrImportFailed.Message, result.Errors...)
+	default:
+		result.Imported, result.IDs = len(ids), ids
+	}
+	return result, nil
+}
+
+func rowField(i int, field string) string {
+	if field == "" {
+		return fmt.Sprintf("rows[%d]", i)
+	}
+	return fmt.Sprintf("rows[%d].%s", i, field)
+}
+
+// decodeCSV reads rows with a header line naming their fields by json
+// name. Empty cells leave a field at its zero value. Cells that do not
+// parse are reported per row rather than failing the whole import.
+func decodeCSV[T any](r io.Reader) ([]T, [][]FieldError, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, nil, readError(err)
+	}
+
+	fieldIndex := make(map[string]int)
+	typ := reflect.TypeFor[T]()
+	for i := range typ.NumField() {
+		fieldIndex[FieldName(typ.Field(i))] = i
+	}
+	columns := make([]int, len(header))
+	seen := make(map[string]bool)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		index, ok := fieldIndex[name]
+		if !ok {
+			return nil, nil, ErrMalformedImport.Withf("unknown column %q", name)
+		}
+		if seen[name] {
+			return nil, nil, ErrMalformedImport.Withf("column %q appears more than once", name)
+		}
+		seen[name] = true
+		header[i], columns[i] = name, index
+	}
+
+	var (
+		rows    []T
+		rowErrs [][]FieldError
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, readError(err)
+		}
+		if len(rows) == maxImportRows {
+			return nil, nil, ErrImportTooLarge.Withf("import has more than %d rows", maxImportRows)
+		}
+
+		var row T
+		var errs []FieldError
+		v := reflect.ValueOf(&row).Elem()
+		for i, cell := range record {
+			field := v.Field(columns[i])
+			if !setCell(field, strings.TrimSpace(cell)) {
+				errs = append(errs, FieldError{Field: header[i], Code: "type", Message: "must be of type " + field.Type().String()})
+			}
+		}
+		rows = append(rows, row)
+		rowErrs = append(rowErrs, errs)
+	}
+	return rows, rowErrs, nil
+}
+
+func setCell(field reflect.Value, cell string) bool {
+	if cell == "" {
+		return true
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return false
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+		if err != nil {
+			return false
+		}
+		field.SetUint(n)
+	default:
+		return false
+	}
+	return true
+}
+
+// decodeJSON reads rows from a JSON array of objects. The array is read
+// one element at a time, so the row limit applies while decoding.
+func decodeJSON[T any](r io.Reader) ([]T, [][]FieldError, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, nil, readError(err)
+	}
+	if tok != json.Delim('[') {
+		return nil, nil, ErrMalformedImport.Withf("import must be a JSON array of objects")
+	}
+
+	var (
+		rows    []T
+		rowErrs [][]FieldError
+	)
+	for dec.More() {
+		if len(rows) == maxImportRows {
+			return nil, nil, ErrImportTooLarge.Withf("import has more than %d rows", maxImportRows)
+		}
+
+		var data json.RawMessage
+		if err := dec.Decode(&data); err != nil {
+			return nil, nil, readError(err)
+		}
+
+		var row T
+		var errs []FieldError
+		err := json.Unmarshal(data, &row)
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr) && typeErr.Field != "":
+			errs = []FieldError{{Field: typeErr.Field, Code: "type", Message: "must be of type " + typeErr.Type.String()}}
+		case err != nil:
+			errs = []FieldError{{Code: "type", Message: "must be an object"}}
+		}
+		rows = append(rows, row)
+		rowErrs = append(rowErrs, errs)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, readError(err)
+	}
+	return rows, rowErrs, nil
+}
+
+// readError reports a body over the request size limit as too large and
+// anything else as unreadable.
+func readError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return ErrImportTooLarge.Withf("import is larger than %d bytes", maxErr.Limit)
+	}
+	return ErrMalformedImport.Withf("%s", err)
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestImportTeamsFromCSV(t *testing.T) {
+	f := newFixture(t)
+	imports := service.NewImportService(f.store, f.teams, f.players, f.matches)
+
+	csv := "\ufeffname,founded_year,hq_city\nPersib,1933,Bandung\n\"Bali United, FC\",2015,Gianyar\n"
+	result, err := imports.Import(f.ctx, service.ImportTeams, service.ImportCSV, strings.NewReader(csv), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 2 || len(result.IDs) != 2 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v, want 2 teams imported", result)
+	}
+
+	team, err := f.teams.FindByID(f.ctx, result.IDs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "Bali United, FC" || team.FoundedYear != 2015 || team.HQCity != "Gianyar" {
+		t.Errorf("imported team = %+v", team)
+	}
+}
+
+func TestImportPlayersReportsEveryRow(t *testing.T) {
+	f := newFixture(t)
+	imports := service.NewImportService(f.store, f.teams, f.players, f.matches)
+	team := f.team(t, "Persib")
+	f.player(t, team, 7)
+
+	json := `[
+		{"team_id": 1, "name": "Ciro", "position": "penyerang", "jersey_number": 9},
+		{"team_id": 1, "name": "Marc", "position": "striker", "jersey_number": 8},
+		{"team_id": 1, "name": "David", "position": "penyerang", "jersey_number": 9},
+		{"team_id": 1, "name": "Beckham", "position": "gelandang", "jersey_number": 7},
+		{"team_id": 99, "name": "Tyronne", "position": "bertahan", "jersey_number": 4},
+		{"team_id": 1, "name": "Teja", "position": "gelandang", "jersey_number": "ten"}
+	]`
+	want := []service.FieldError{
+		{Field: "rows[1].position", Code: "oneof", Message: "must be one of: penyerang, gelandang, bertahan, penjaga_gawang"},
+		{Field: "rows[2].jersey_number", Code: "jersey_number_taken", Message: service.ErrJerseyNumberTaken.Message},
+		{Field: "rows[3].jersey_number", Code: "jersey_number_taken", Message: service.ErrJerseyNumberTaken.Message},
+		{Field: "rows[4].team_id", Code: "team_not_found", Message: service.ErrTeamNotFound.Message},
+		{Field: "rows[5].jersey_number", Code: "type", Message: "must be of type int"},
+	}
+
+	result, err := imports.Import(f.ctx, service.ImportPlayers, service.ImportJSON, strings.NewReader(json), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.DryRun || result.Rows != 6 || result.Imported != 0 || !slices.Equal(result.Errors, want) {
+		t.Fatalf("dry run = %+v, want errors %+v", result, want)
+	}
+
+	_, err = imports.Import(f.ctx, service.ImportPlayers, service.ImportJSON, strings.NewReader(json), false)
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) || !errors.Is(err, service.ErrImportFailed) || !slices.Equal(serviceErr.Fields, want) {
+		t.Fatalf("import = %v, want %v with the dry run errors", err, service.ErrImportFailed)
+	}
+
+	players, err := f.players.FindByTeam(f.ctx, team.ID, dto.PlayerListQuery{}, dto.Pagination{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players.Items) != 1 {
+		t.Errorf("%d players after failed import, want only the existing one", len(players.Items))
+	}
+}
+
+func TestImportRejectsUnreadableInput(t *testing.T) {
+	f := newFixture(t)
+	imports := service.NewImportService(f.store, f.teams, f.players, f.matches)
+
+	tests := []struct {
+		name   string
+		kind   string
+		format string
+		input  string
+		want   error
+	}{
+		{"unknown kind", "coaches", service.ImportCSV, "name\nRudy\n", service.ErrInvalidImportKind},
+		{"unknown format", service.ImportTeams, "xlsx", "", service.ErrInvalidImportFormat},
+		{"unknown column", service.ImportTeams, service.ImportCSV, "name,stadium\nPersib,GBLA\n", service.ErrMalformedImport},
+		{"ragged rows", service.ImportTeams, service.ImportCSV, "name,founded_year\nPersib\n", service.ErrMalformedImport},
+		{"not an array", service.ImportFixtures, service.ImportJSON, `{"match_date": "2026-08-01"}`, service.ErrMalformedImport},
+		{"truncated array", service.ImportTeams, service.ImportJSON, `[{"name": "Persib"}, {"name": `, service.ErrMalformedImport},
+		{"too many JSON rows", service.ImportTeams, service.ImportJSON, "[" + strings.Repeat("{},", 5000) + "{}]", service.ErrImportTooLarge},
+		{"header only", service.ImportTeams, service.ImportCSV, "name,founded_year\n", service.ErrEmptyImport},
+		{"empty", service.ImportFixtures, service.ImportJSON, "", service.ErrEmptyImport},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := imports.Import(f.ctx, tt.kind, tt.format, strings.NewReader(tt.input), false)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestImportStopsAtTheSizeLimit(t *testing.T) {
+	f := newFixture(t)
+	imports := service.NewImportService(f.store, f.teams, f.players, f.matches)
+
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{"JSON", service.ImportJSON, `[{"name": "` + strings.Repeat("x", 2048) + `"}]`},
+		{"single CSV record", service.ImportCSV, "name\n" + strings.Repeat("x", 2048) + "\n"},
+		{"CSV header", service.ImportCSV, strings.Repeat("x", 2048)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := http.MaxBytesReader(nil, io.NopCloser(strings.NewReader(tt.input)), 1024)
+			_, err := imports.Import(f.ctx, service.ImportTeams, tt.format, body, false)
+			if !errors.Is(err, service.ErrImportTooLarge) {
+				t.Fatalf("expected %v, got %v", service.ErrImportTooLarge, err)
+			}
+		})
+	}
+}
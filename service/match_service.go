@@ -1,186 +1,386 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/metrics"
 	"github.com/pranotoism/football-go/model"
 	"github.com/pranotoism/football-go/repository"
-	"gorm.io/gorm"
+	"github.com/pranotoism/football-go/tracing"
 )
 
+// MatchService schedules matches and records their results. Matches
+// scheduled without a time zone, and date filters, use loc.
 type MatchService struct {
-	matchRepo  *repository.MatchRepository
-	teamRepo   *repository.TeamRepository
-	goalRepo   *repository.GoalRepository
+	tx           repository.Transactor
+	matchRepo    MatchRepository
+	teamRepo     TeamRepository
+	goalRepo     GoalRepository
+	revisionRepo MatchRevisionRepository
+	loc          *time.Location
 }
 
-func NewMatchService(matchRepo *repository.MatchRepository, teamRepo *repository.TeamRepository, goalRepo *repository.GoalRepository) *MatchService {
-	return &MatchService{matchRepo: matchRepo, teamRepo: teamRepo, goalRepo: goalRepo}
+func NewMatchService(tx repository.Transactor, matchRepo MatchRepository, teamRepo TeamRepository, goalRepo GoalRepository, revisionRepo MatchRevisionRepository, loc *time.Location) *MatchService {
+	return &MatchService{tx: tx, matchRepo: matchRepo, teamRepo: teamRepo, goalRepo: goalRepo, revisionRepo: revisionRepo, loc: loc}
 }
 
-func (s *MatchService) Create(req dto.CreateMatchRequest) (*model.Match, error) {
+func (s *MatchService) Create(ctx context.Context, req dto.CreateMatchRequest) (*model.Match, error) {
+	ctx, span := tracing.Start(ctx, "MatchService.Create")
+	defer span.End()
+
 	if req.HomeTeamID == req.AwayTeamID {
-		return nil, errors.New("home team and away team cannot be the same")
+		return nil, ErrSameTeams
 	}
 
-	if !s.teamRepo.Exists(req.HomeTeamID) {
-		return nil, errors.New("home team not found")
+	if !s.teamRepo.Exists(ctx, req.HomeTeamID) {
+		return nil, ErrHomeTeamNotFound
 	}
-	if !s.teamRepo.Exists(req.AwayTeamID) {
-		return nil, errors.New("away team not found")
+	if !s.teamRepo.Exists(ctx, req.AwayTeamID) {
+		return nil, ErrAwayTeamNotFound
+	}
+
+	zone := req.TimeZone
+	if zone == "" {
+		zone = s.loc.String()
+	}
+	kickoffAt, err := kickoff(req.MatchDate, req.MatchTime, zone)
+	if err != nil {
+		return nil, err
 	}
 
 	match := &model.Match{
-		MatchDate:  req.MatchDate,
-		MatchTime:  req.MatchTime,
-		HomeTeamID: req.HomeTeamID,
-		AwayTeamID: req.AwayTeamID,
+		KickoffAt:   kickoffAt,
+		TimeZone:    zone,
+		HomeTeamID:  req.HomeTeamID,
+		AwayTeamID:  req.AwayTeamID,
+		Competition: req.Competition,
 	}
 
-	if err := s.matchRepo.Create(match); err != nil {
+	if err := s.matchRepo.Create(ctx, match); err != nil {
 		return nil, err
 	}
 
-	return s.matchRepo.FindByID(match.ID)
+	return s.matchRepo.FindByID(ctx, match.ID)
 }
 
-func (s *MatchService) FindAll(page, perPage int) ([]model.Match, int64, error) {
-	return s.matchRepo.FindAll(page, perPage)
+func (s *MatchService) FindAll(ctx context.Context, filter dto.MatchListQuery, p dto.Pagination) (repository.Page[model.Match], error) {
+	ctx, span := tracing.Start(ctx, "MatchService.FindAll")
+	defer span.End()
+
+	q, err := matchQuery(filter, p, s.loc)
+	if err != nil {
+		return repository.Page[model.Match]{}, err
+	}
+	return s.matchRepo.FindAll(ctx, q)
 }
 
-func (s *MatchService) FindByID(id uint) (*model.Match, error) {
-	match, err := s.matchRepo.FindByID(id)
+// kickoff reads a local match date and time in the time zone called zone.
+func kickoff(date, clock, zone string) (time.Time, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil || zone == "" || strings.EqualFold(zone, "local") {
+		return time.Time{}, ErrInvalidTimeZone.Withf("unknown time zone %q", zone)
+	}
+	if _, err := time.Parse(model.DateLayout, date); err != nil {
+		return time.Time{}, ErrInvalidDate.Withf("match date %q is not a YYYY-MM-DD date", date)
+	}
+	for _, layout := range model.TimeLayouts {
+		if at, err := time.ParseInLocation(model.DateLayout+" "+layout, date+" "+clock, loc); err == nil {
+			return at.UTC(), nil
+		}
+	}
+	return time.Time{}, ErrInvalidKickoffTime.Withf("match time %q is not an HH:MM or HH:MM:SS time", clock)
+}
+
+// matchQuery builds the query for the page p of the matches that filter
+// selects. Its dates are days in loc.
+func matchQuery(filter dto.MatchListQuery, p dto.Pagination, loc *time.Location) (repository.Query, error) {
+	q, err := newQuery(repository.MatchSchema, filter.Sort, p)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("match not found")
+		return repository.Query{}, err
+	}
+	var from, to time.Time
+	if filter.DateFrom != "" {
+		if from, err = time.ParseInLocation(time.DateOnly, filter.DateFrom, loc); err != nil {
+			return repository.Query{}, ErrInvalidDate.Withf("date_from %q is not a YYYY-MM-DD date", filter.DateFrom)
+		}
+		q.Where(repository.OpGte, from.UTC(), "kickoff_at")
+	}
+	if filter.DateTo != "" {
+		if to, err = time.ParseInLocation(time.DateOnly, filter.DateTo, loc); err != nil {
+			return repository.Query{}, ErrInvalidDate.Withf("date_to %q is not a YYYY-MM-DD date", filter.DateTo)
+		}
+		q.Where(repository.OpLt, to.AddDate(0, 0, 1).UTC(), "kickoff_at")
+	}
+	if filter.DateFrom != "" && filter.DateTo != "" && to.Before(from) {
+		return repository.Query{}, ErrInvalidRange.Withf("date_to must not be before date_from")
+	}
+	if filter.TeamID != 0 {
+		q.Where(repository.OpEq, filter.TeamID, "home_team_id", "away_team_id")
+	}
+	if filter.Played != nil {
+		op := repository.OpNull
+		if *filter.Played {
+			op = repository.OpNotNull
+		}
+		q.Where(op, nil, "home_score")
+	}
+	return q, nil
+}
+
+func (s *MatchService) FindByID(ctx context.Context, id uint) (*model.Match, error) {
+	ctx, span := tracing.Start(ctx, "MatchService.FindByID")
+	defer span.End()
+
+	match, err := s.matchRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMatchNotFound
 		}
 		return nil, err
 	}
 	return match, nil
 }
 
-func (s *MatchService) Update(id uint, req dto.UpdateMatchRequest) (*model.Match, error) {
-	match, err := s.matchRepo.FindByID(id)
+func (s *MatchService) Update(ctx context.Context, id uint, req dto.UpdateMatchRequest) (*model.Match, error) {
+	ctx, span := tracing.Start(ctx, "MatchService.Update")
+	defer span.End()
+
+	match, err := s.matchRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("match not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMatchNotFound
 		}
 		return nil, err
 	}
 
 	if req.HomeTeamID != 0 && req.AwayTeamID != 0 && req.HomeTeamID == req.AwayTeamID {
-		return nil, errors.New("home team and away team cannot be the same")
+		return nil, ErrSameTeams
 	}
 
-	if req.MatchDate != "" {
-		match.MatchDate = req.MatchDate
+	if req.MatchDate != "" || req.MatchTime != "" || req.TimeZone != "" {
+		date, clock, zone := match.MatchDate(), match.MatchTime(), match.TimeZone
+		if req.MatchDate != "" {
+			date = req.MatchDate
+		}
+		if req.MatchTime != "" {
+			clock = req.MatchTime
+		}
+		if req.TimeZone != "" {
+			zone = req.TimeZone
+		}
+		if match.KickoffAt, err = kickoff(date, clock, zone); err != nil {
+			return nil, err
+		}
+		match.TimeZone = zone
 	}
-	if req.MatchTime != "" {
-		match.MatchTime = req.MatchTime
+	if req.Competition != "" {
+		match.Competition = req.Competition
 	}
 	if req.HomeTeamID != 0 {
-		if !s.teamRepo.Exists(req.HomeTeamID) {
-			return nil, errors.New("home team not found")
+		if !s.teamRepo.Exists(ctx, req.HomeTeamID) {
+			return nil, ErrHomeTeamNotFound
 		}
 		match.HomeTeamID = req.HomeTeamID
 	}
 	if req.AwayTeamID != 0 {
-		if !s.teamRepo.Exists(req.AwayTeamID) {
-			return nil, errors.New("away team not found")
+		if !s.teamRepo.Exists(ctx, req.AwayTeamID) {
+			return nil, ErrAwayTeamNotFound
 		}
 		match.AwayTeamID = req.AwayTeamID
 	}
 
-	if err := s.matchRepo.Update(match); err != nil {
+	if err := s.matchRepo.Update(ctx, match); err != nil {
 		return nil, err
 	}
 
-	return s.matchRepo.FindByID(match.ID)
+	return s.matchRepo.FindByID(ctx, match.ID)
 }
 
-func (s *MatchService) Delete(id uint) error {
-	match, err := s.matchRepo.FindByID(id)
+func (s *MatchService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "MatchService.Delete")
+	defer span.End()
+
+	match, err := s.matchRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("match not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMatchNotFound
 		}
 		return err
 	}
 
 	// Cascade soft-delete related goals
-	if err := s.goalRepo.DeleteByMatchID(id); err != nil {
+	return deletionTx(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.goalRepo.DeleteByMatchID(ctx, id); err != nil {
+			return err
+		}
+		return s.matchRepo.Delete(ctx, match)
+	})
+}
+
+// AmendResult replaces the published score and goals of a match. The
+// version being replaced is kept as a revision together with the user who
+// made the correction and the reason for it.
+func (s *MatchService) AmendResult(ctx context.Context, id, userID uint, req dto.AmendResultRequest) (*model.Match, error) {
+	ctx, span := tracing.Start(ctx, "MatchService.AmendResult")
+	defer span.End()
+
+	// The match is checked once locked, so that concurrent amendments each
+	// keep the result the one before replaced.
+	err := s.publishResult(ctx, id, userID, req.Reason, req.HomeScore, req.AwayScore, req.Goals, func(ctx context.Context, match *model.Match) error {
+		if match.HomeScore == nil {
+			return ErrResultNotReported
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.matchRepo.FindByID(ctx, id)
+}
+
+// publishResult makes a result the published one in a single transaction,
+// which locks the match so that concurrent publications queue up behind
+// each other. When the match already has a published result it is kept as
+// a revision before its scores and goals are replaced. inTx, when set, runs
+// first in the same transaction with the locked match, so callers can check
+// it and record their own state atomically.
+func (s *MatchService) publishResult(ctx context.Context, id, userID uint, reason string, homeScore, awayScore int, goals []dto.GoalInput, inTx func(ctx context.Context, match *model.Match) error) error {
+	kind := metrics.ResultInitial
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		match, err := s.matchRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrMatchNotFound
+			}
+			return err
+		}
+		if inTx != nil {
+			if err := inTx(ctx, match); err != nil {
+				return err
+			}
+		}
+		// The match may have changed since the result was checked.
+		if err := validateResult(match, homeScore, awayScore, goals); err != nil {
+			return err
+		}
+
+		if match.HomeScore != nil {
+			kind = metrics.ResultAmendment
+			inputs := make([]dto.GoalInput, len(match.Goals))
+			for i, g := range match.Goals {
+				inputs[i] = dto.GoalInput{PlayerID: g.PlayerID, TeamID: g.TeamID, Minute: g.Minute}
+			}
+			previousGoals, err := json.Marshal(inputs)
+			if err != nil {
+				return err
+			}
+
+			revision, err := s.revisionRepo.NextRevision(ctx, match.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := s.revisionRepo.Create(ctx, &model.MatchResultRevision{
+				MatchID:   match.ID,
+				Revision:  revision,
+				HomeScore: *match.HomeScore,
+				AwayScore: *match.AwayScore,
+				Goals:     string(previousGoals),
+				Reason:    reason,
+				ChangedBy: userID,
+			}); err != nil {
+				return err
+			}
+
+			if err := s.goalRepo.PurgeByMatchID(ctx, match.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := s.matchRepo.UpdateScore(ctx, match.ID, homeScore, awayScore); err != nil {
+			return err
+		}
+		return s.goalRepo.CreateBatch(ctx, buildGoals(match.ID, goals))
+	})
+	if err != nil {
 		return err
 	}
 
-	return s.matchRepo.Delete(match)
+	metrics.ResultsReported.WithLabelValues(kind).Inc()
+	metrics.GoalsRecorded.Add(float64(len(goals)))
+	return nil
 }
 
-func (s *MatchService) ReportResult(id uint, req dto.ReportResultRequest) (*model.Match, error) {
-	match, err := s.matchRepo.FindByID(id)
+func (s *MatchService) FindRevisions(ctx context.Context, id uint) ([]dto.MatchResultRevision, error) {
+	ctx, span := tracing.Start(ctx, "MatchService.FindRevisions")
+	defer span.End()
+
+	if _, err := s.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.revisionRepo.FindByMatchID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("match not found")
-		}
 		return nil, err
 	}
 
-	if match.HomeScore != nil {
-		return nil, errors.New("match result already reported")
+	result := make([]dto.MatchResultRevision, len(revisions))
+	for i, r := range revisions {
+		var goals []dto.GoalInput
+		if err := json.Unmarshal([]byte(r.Goals), &goals); err != nil {
+			return nil, err
+		}
+		result[i] = dto.MatchResultRevision{
+			Revision:  r.Revision,
+			HomeScore: r.HomeScore,
+			AwayScore: r.AwayScore,
+			Goals:     goals,
+			Reason:    r.Reason,
+			ChangedBy: r.ChangedBy,
+			ChangedAt: r.CreatedAt,
+		}
 	}
+	return result, nil
+}
 
+func validateResult(match *model.Match, homeScore, awayScore int, goals []dto.GoalInput) error {
 	// Validate goal counts per team
 	homeGoals := 0
 	awayGoals := 0
-	for _, g := range req.Goals {
+	for _, g := range goals {
 		if g.TeamID == match.HomeTeamID {
 			homeGoals++
 		} else if g.TeamID == match.AwayTeamID {
 			awayGoals++
 		} else {
-			return nil, fmt.Errorf("goal team_id %d does not belong to either team in this match", g.TeamID)
+			return ErrGoalTeamMismatch.Withf("goal team_id %d does not belong to either team in this match", g.TeamID)
 		}
 	}
 
-	if homeGoals != req.HomeScore {
-		return nil, fmt.Errorf("home goal count (%d) does not match home_score (%d)", homeGoals, req.HomeScore)
+	if homeGoals != homeScore {
+		return ErrGoalCountMismatch.Withf("home goal count (%d) does not match home_score (%d)", homeGoals, homeScore)
 	}
-	if awayGoals != req.AwayScore {
-		return nil, fmt.Errorf("away goal count (%d) does not match away_score (%d)", awayGoals, req.AwayScore)
+	if awayGoals != awayScore {
+		return ErrGoalCountMismatch.Withf("away goal count (%d) does not match away_score (%d)", awayGoals, awayScore)
 	}
+	return nil
+}
 
-	// Transaction: update scores + create goals
-	db := s.matchRepo.DB()
-	err = db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Model(&model.Match{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"home_score": req.HomeScore,
-			"away_score": req.AwayScore,
-		}).Error; err != nil {
-			return err
-		}
-
-		if len(req.Goals) > 0 {
-			goals := make([]model.Goal, len(req.Goals))
-			for i, g := range req.Goals {
-				goals[i] = model.Goal{
-					MatchID:  id,
-					PlayerID: g.PlayerID,
-					TeamID:   g.TeamID,
-					Minute:   g.Minute,
-				}
-			}
-			if err := tx.Create(&goals).Error; err != nil {
-				return err
-			}
+func buildGoals(matchID uint, inputs []dto.GoalInput) []model.Goal {
+	goals := make([]model.Goal, len(inputs))
+	for i, g := range inputs {
+		goals[i] = model.Goal{
+			MatchID:  matchID,
+			PlayerID: g.PlayerID,
+			TeamID:   g.TeamID,
+			Minute:   g.Minute,
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
 	}
-
-	return s.matchRepo.FindByID(id)
+	return goals
 }
@@ -0,0 +1,206 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMatchServiceCreate(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+
+	tests := []struct {
+		name    string
+		req     dto.CreateMatchRequest
+		wantErr string
+	}{
+		{"valid", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: away.ID}, ""},
+		{"same team", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: home.ID}, "cannot be the same"},
+		{"unknown home team", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", HomeTeamID: 99, AwayTeamID: away.ID}, "home team not found"},
+		{"unknown away team", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: 99}, "away team not found"},
+		{"date in words", dto.CreateMatchRequest{MatchDate: "tomorrow", MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: away.ID}, `"tomorrow" is not a YYYY-MM-DD date`},
+		{"time out of range", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "25:99", HomeTeamID: home.ID, AwayTeamID: away.ID}, `"25:99" is not an HH:MM or HH:MM:SS time`},
+		{"time with seconds", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00:00", HomeTeamID: home.ID, AwayTeamID: away.ID}, ""},
+		{"unknown time zone", dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", TimeZone: "Asia/Bandung", HomeTeamID: home.ID, AwayTeamID: away.ID}, `unknown time zone "Asia/Bandung"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := f.matches.Create(f.ctx, tt.req)
+			checkErr(t, err, tt.wantErr)
+			if err == nil && (match.HomeTeam == nil || match.AwayTeam == nil) {
+				t.Error("created match is returned without its teams")
+			}
+		})
+	}
+}
+
+func TestMatchServiceUpdate(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	match := f.match(t, home, away)
+
+	tests := []struct {
+		name    string
+		req     dto.UpdateMatchRequest
+		wantErr string
+	}{
+		{"same team", dto.UpdateMatchRequest{HomeTeamID: away.ID, AwayTeamID: away.ID}, "cannot be the same"},
+		{"unknown team", dto.UpdateMatchRequest{AwayTeamID: 99}, "away team not found"},
+		{"invalid time", dto.UpdateMatchRequest{MatchTime: "sore"}, "is not an HH:MM or HH:MM:SS time"},
+		{"reschedule", dto.UpdateMatchRequest{MatchTime: "20:30"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.matches.Update(f.ctx, match.ID, tt.req)
+			checkErr(t, err, tt.wantErr)
+		})
+	}
+
+	// Moving the match to a venue in another time zone keeps its local
+	// date and time.
+	moved, err := f.matches.Update(f.ctx, match.ID, dto.UpdateMatchRequest{TimeZone: "Asia/Jayapura"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 5, 10, 11, 30, 0, 0, time.UTC); !moved.KickoffAt.Equal(want) || moved.MatchDate() != "2026-05-10" || moved.MatchTime() != "20:30" {
+		t.Errorf("moved match kicks off at %v, %s %s local, want %v", moved.KickoffAt, moved.MatchDate(), moved.MatchTime(), want)
+	}
+}
+
+func TestMatchServiceDateFilter(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	for _, kickoff := range [][2]string{{"2026-05-09", "23:30"}, {"2026-05-10", "00:30"}, {"2026-05-10", "23:30"}, {"2026-05-11", "00:30"}} {
+		if _, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: kickoff[0], MatchTime: kickoff[1], HomeTeamID: home.ID, AwayTeamID: away.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := f.matches.FindAll(f.ctx, dto.MatchListQuery{DateFrom: "2026-05-10", DateTo: "2026-05-10", Sort: "kickoff_at"}, dto.Pagination{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []uint
+	for _, m := range page.Items {
+		got = append(got, m.ID)
+	}
+	if !slices.Equal(got, []uint{2, 3}) {
+		t.Errorf("matches on 2026-05-10 in Jakarta = %v, want [2 3]", got)
+	}
+}
+
+func TestMatchServiceAmendResult(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	unplayed := f.match(t, home, away)
+	match := f.match(t, home, away)
+	f.publish(t, match, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 10}}})
+
+	goal := func(minute int) dto.GoalInput {
+		return dto.GoalInput{PlayerID: striker.ID, TeamID: home.ID, Minute: minute}
+	}
+
+	tests := []struct {
+		name    string
+		matchID uint
+		req     dto.AmendResultRequest
+		wantErr string
+	}{
+		{"not reported yet", unplayed.ID, dto.AmendResultRequest{Reason: "typo"}, "has not been reported yet"},
+		{"score mismatch", match.ID, dto.AmendResultRequest{HomeScore: 2, Goals: []dto.GoalInput{goal(10)}, Reason: "typo"}, "home goal count (1) does not match home_score (2)"},
+		{"corrected", match.ID, dto.AmendResultRequest{HomeScore: 2, Goals: []dto.GoalInput{goal(10), goal(80)}, Reason: "missed goal"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.matches.AmendResult(f.ctx, tt.matchID, 2, tt.req)
+			checkErr(t, err, tt.wantErr)
+		})
+	}
+
+	amended, err := f.matches.FindByID(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *amended.HomeScore != 2 || len(amended.Goals) != 2 {
+		t.Errorf("amended result = %d with %d goals, want 2 with 2 goals", *amended.HomeScore, len(amended.Goals))
+	}
+
+	revisions, err := f.matches.FindRevisions(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 1 || revisions[0].HomeScore != 1 || revisions[0].Reason != "missed goal" {
+		t.Errorf("revisions = %+v, want the original 1-0 result", revisions)
+	}
+}
+
+func TestMatchServiceAmendsTheLatestResult(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	match := f.match(t, home, away)
+	f.publish(t, match, dto.ReportResultRequest{})
+
+	// Another amendment holds the match while this one is requested
+	amended := make(chan error)
+	err := f.store.WithinTx(f.ctx, func(ctx context.Context) error {
+		go func() {
+			_, err := f.matches.AmendResult(f.ctx, match.ID, 2, dto.AmendResultRequest{Reason: "abandoned"})
+			amended <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		return f.store.Matches().UpdateScore(ctx, match.ID, 0, 3)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-amended; err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := f.matches.FindRevisions(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 1 || revisions[0].AwayScore != 3 {
+		t.Errorf("revisions = %+v, want the 0-3 result the amendment replaced", revisions)
+	}
+}
+
+func TestMatchServiceCountsPublishedResults(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	match := f.match(t, home, away)
+
+	initial := testutil.ToFloat64(metrics.ResultsReported.WithLabelValues(metrics.ResultInitial))
+	amendments := testutil.ToFloat64(metrics.ResultsReported.WithLabelValues(metrics.ResultAmendment))
+	goals := testutil.ToFloat64(metrics.GoalsRecorded)
+
+	f.publish(t, match, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 10}}})
+	if _, err := f.matches.AmendResult(f.ctx, match.ID, 1, dto.AmendResultRequest{Reason: "disallowed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := testutil.ToFloat64(metrics.ResultsReported.WithLabelValues(metrics.ResultInitial)) - initial; got != 1 {
+		t.Errorf("initial results counted %v times, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.ResultsReported.WithLabelValues(metrics.ResultAmendment)) - amendments; got != 1 {
+		t.Errorf("amendments counted %v times, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.GoalsRecorded) - goals; got != 1 {
+		t.Errorf("goals counted %v, want 1", got)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/matchsheet"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+const (
+	SheetHTML = "html"
+	SheetPDF  = "pdf"
+)
+
+// MatchSheetService renders match reports as printable sheets, with the
+// templates of the competition of the match.
+type MatchSheetService struct {
+	reports    *ReportService
+	playerRepo PlayerRepository
+	renderer   *matchsheet.Renderer
+}
+
+func NewMatchSheetService(reports *ReportService, playerRepo PlayerRepository, renderer *matchsheet.Renderer) *MatchSheetService {
+	return &MatchSheetService{reports: reports, playerRepo: playerRepo, renderer: renderer}
+}
+
+// Render writes the sheet of a match to w as HTML or PDF.
+func (s *MatchSheetService) Render(ctx context.Context, id uint, format string, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "MatchSheetService.Render")
+	defer span.End()
+
+	if format != SheetHTML && format != SheetPDF {
+		return ErrInvalidReportFormat
+	}
+	report, err := s.reports.GetMatchReport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Only played matches have a report
+	sheet := &matchsheet.Sheet{MatchReport: *report, Played: true, GeneratedAt: time.Now()}
+	if sheet.HomeSquad, err = s.squad(ctx, report.HomeTeam.ID); err != nil {
+		return err
+	}
+	if sheet.AwaySquad, err = s.squad(ctx, report.AwayTeam.ID); err != nil {
+		return err
+	}
+
+	if format == SheetPDF {
+		return s.renderer.PDF(ctx, w, sheet)
+	}
+	return s.renderer.HTML(w, sheet)
+}
+
+// squad lists the current players of a team by jersey number.
+func (s *MatchSheetService) squad(ctx context.Context, teamID uint) ([]matchsheet.SquadPlayer, error) {
+	q, err := newQuery(repository.PlayerSchema, "jersey_number,name", dto.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+	find := func(ctx context.Context, q repository.Query) (repository.Page[model.Player], error) {
+		return s.playerRepo.FindByTeam(ctx, teamID, q)
+	}
+
+	var squad []matchsheet.SquadPlayer
+	err = eachPage(ctx, repository.PlayerSchema, q, find, func(players []model.Player) error {
+		for _, p := range players {
+			squad = append(squad, matchsheet.SquadPlayer{JerseyNumber: p.JerseyNumber, Name: p.Name, Position: p.Position})
+		}
+		return nil
+	})
+	return squad, err
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/matchsheet"
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestRenderMatchSheet(t *testing.T) {
+	f := newFixture(t)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "liga-1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	squads := "{{range .HomeSquad}}{{.JerseyNumber}} {{end}}/ {{range .AwaySquad}}{{.JerseyNumber}} {{end}}"
+	if err := os.WriteFile(filepath.Join(dir, "liga-1", "match.html"), []byte(squads), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	renderer, err := matchsheet.New(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheets := service.NewMatchSheetService(service.NewReportService(f.store.Matches()), f.store.Players(), renderer)
+
+	home, away := f.team(t, "Persib"), f.team(t, "Persija")
+	scorer := f.player(t, home, 9)
+	f.player(t, home, 1)
+	f.player(t, away, 10)
+	match, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", Competition: "Liga 1", HomeTeamID: home.ID, AwayTeamID: away.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := sheets.Render(f.ctx, match.ID, service.SheetHTML, &buf); !errors.Is(err, service.ErrResultNotReported) {
+		t.Fatalf("sheet of an unplayed match: err = %v", err)
+	}
+
+	f.publish(t, match, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: scorer.ID, TeamID: home.ID, Minute: 30}}})
+	if err := sheets.Render(f.ctx, match.ID, service.SheetHTML, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "1 9 / 10 "; got != want {
+		t.Errorf("sheet = %q, want the squads by jersey number from the competition's template, %q", got, want)
+	}
+
+	buf.Reset()
+	if err := sheets.Render(f.ctx, match.ID, service.SheetPDF, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "%PDF-") {
+		t.Error("PDF sheet is not a PDF")
+	}
+
+	if err := sheets.Render(f.ctx, match.ID, "docx", &buf); !errors.Is(err, service.ErrInvalidReportFormat) {
+		t.Errorf("unknown format: err = %v", err)
+	}
+	if err := sheets.Render(f.ctx, 999, service.SheetPDF, &buf); !errors.Is(err, service.ErrMatchNotFound) {
+		t.Errorf("unknown match: err = %v", err)
+	}
+}
@@ -1,30 +1,35 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/pranotoism/football-go/dto"
 	"github.com/pranotoism/football-go/model"
 	"github.com/pranotoism/football-go/repository"
-	"gorm.io/gorm"
+	"github.com/pranotoism/football-go/tracing"
 )
 
 type PlayerService struct {
-	playerRepo *repository.PlayerRepository
-	teamRepo   *repository.TeamRepository
+	playerRepo PlayerRepository
+	teamRepo   TeamRepository
+	goalRepo   GoalRepository
 }
 
-func NewPlayerService(playerRepo *repository.PlayerRepository, teamRepo *repository.TeamRepository) *PlayerService {
-	return &PlayerService{playerRepo: playerRepo, teamRepo: teamRepo}
+func NewPlayerService(playerRepo PlayerRepository, teamRepo TeamRepository, goalRepo GoalRepository) *PlayerService {
+	return &PlayerService{playerRepo: playerRepo, teamRepo: teamRepo, goalRepo: goalRepo}
 }
 
-func (s *PlayerService) Create(teamID uint, req dto.CreatePlayerRequest) (*model.Player, error) {
-	if !s.teamRepo.Exists(teamID) {
-		return nil, errors.New("team not found")
+func (s *PlayerService) Create(ctx context.Context, teamID uint, req dto.CreatePlayerRequest) (*model.Player, error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Create")
+	defer span.End()
+
+	if !s.teamRepo.Exists(ctx, teamID) {
+		return nil, ErrTeamNotFound
 	}
 
-	if s.playerRepo.IsJerseyNumberTaken(teamID, req.JerseyNumber, 0) {
-		return nil, errors.New("jersey number already taken in this team")
+	if s.playerRepo.IsJerseyNumberTaken(ctx, teamID, req.JerseyNumber, 0) {
+		return nil, ErrJerseyNumberTaken
 	}
 
 	player := &model.Player{
@@ -36,42 +41,72 @@ func (s *PlayerService) Create(teamID uint, req dto.CreatePlayerRequest) (*model
 		JerseyNumber: req.JerseyNumber,
 	}
 
-	if err := s.playerRepo.Create(player); err != nil {
+	if err := s.playerRepo.Create(ctx, player); err != nil {
 		return nil, err
 	}
-	return s.playerRepo.FindByID(player.ID)
+	return s.playerRepo.FindByID(ctx, player.ID)
 }
 
-func (s *PlayerService) FindByTeam(teamID uint, page, perPage int) ([]model.Player, int64, error) {
-	if !s.teamRepo.Exists(teamID) {
-		return nil, 0, errors.New("team not found")
+func (s *PlayerService) FindByTeam(ctx context.Context, teamID uint, filter dto.PlayerListQuery, p dto.Pagination) (repository.Page[model.Player], error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.FindByTeam")
+	defer span.End()
+
+	q, err := playerQuery(filter, p)
+	if err != nil {
+		return repository.Page[model.Player]{}, err
+	}
+
+	if !s.teamRepo.Exists(ctx, teamID) {
+		return repository.Page[model.Player]{}, ErrTeamNotFound
 	}
-	return s.playerRepo.FindByTeam(teamID, page, perPage)
+	return s.playerRepo.FindByTeam(ctx, teamID, q)
 }
 
-func (s *PlayerService) FindByID(id uint) (*model.Player, error) {
-	player, err := s.playerRepo.FindByID(id)
+// playerQuery builds the query for the page p of the players that filter
+// selects.
+func playerQuery(filter dto.PlayerListQuery, p dto.Pagination) (repository.Query, error) {
+	q, err := newQuery(repository.PlayerSchema, filter.Sort, p)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("player not found")
+		return repository.Query{}, err
+	}
+	if filter.Name != "" {
+		q.Where(repository.OpContains, filter.Name, "name")
+	}
+	if filter.Position != "" {
+		q.Where(repository.OpEq, filter.Position, "position")
+	}
+	return q, nil
+}
+
+func (s *PlayerService) FindByID(ctx context.Context, id uint) (*model.Player, error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.FindByID")
+	defer span.End()
+
+	player, err := s.playerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPlayerNotFound
 		}
 		return nil, err
 	}
 	return player, nil
 }
 
-func (s *PlayerService) Update(id uint, req dto.UpdatePlayerRequest) (*model.Player, error) {
-	player, err := s.playerRepo.FindByID(id)
+func (s *PlayerService) Update(ctx context.Context, id uint, req dto.UpdatePlayerRequest) (*model.Player, error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Update")
+	defer span.End()
+
+	player, err := s.playerRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("player not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPlayerNotFound
 		}
 		return nil, err
 	}
 
 	if req.JerseyNumber != 0 && req.JerseyNumber != player.JerseyNumber {
-		if s.playerRepo.IsJerseyNumberTaken(player.TeamID, req.JerseyNumber, id) {
-			return nil, errors.New("jersey number already taken in this team")
+		if s.playerRepo.IsJerseyNumberTaken(ctx, player.TeamID, req.JerseyNumber, id) {
+			return nil, ErrJerseyNumberTaken
 		}
 		player.JerseyNumber = req.JerseyNumber
 	}
@@ -89,19 +124,27 @@ func (s *PlayerService) Update(id uint, req dto.UpdatePlayerRequest) (*model.Pla
 		player.Position = req.Position
 	}
 
-	if err := s.playerRepo.Update(player); err != nil {
+	if err := s.playerRepo.Update(ctx, player); err != nil {
 		return nil, err
 	}
 	return player, nil
 }
 
-func (s *PlayerService) Delete(id uint) error {
-	player, err := s.playerRepo.FindByID(id)
+func (s *PlayerService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "PlayerService.Delete")
+	defer span.End()
+
+	player, err := s.playerRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("player not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPlayerNotFound
 		}
 		return err
 	}
-	return s.playerRepo.Delete(player)
+
+	if s.goalRepo.CountActiveByPlayerID(ctx, id) > 0 {
+		return ErrPlayerHasGoals
+	}
+
+	return s.playerRepo.Delete(ctx, player)
 }
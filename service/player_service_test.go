@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+)
+
+func TestPlayerServiceJerseyNumbers(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	f.player(t, home, 10)
+	other := f.player(t, home, 7)
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			teamID  uint
+			jersey  int
+			wantErr string
+		}{
+			{"taken in team", home.ID, 10, "jersey number already taken"},
+			{"free in team", home.ID, 11, ""},
+			{"taken in other team only", away.ID, 10, ""},
+			{"unknown team", 99, 1, "team not found"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := f.players.Create(f.ctx, tt.teamID, dto.CreatePlayerRequest{Name: "New", Position: "bertahan", JerseyNumber: tt.jersey})
+				checkErr(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			jersey  int
+			wantErr string
+		}{
+			{"own number", 7, ""},
+			{"taken", 10, "jersey number already taken"},
+			{"free", 8, ""},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := f.players.Update(f.ctx, other.ID, dto.UpdatePlayerRequest{JerseyNumber: tt.jersey})
+				checkErr(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("deleted player frees number", func(t *testing.T) {
+		leaving := f.player(t, away, 5)
+		if err := f.players.Delete(f.ctx, leaving.ID); err != nil {
+			t.Fatal(err)
+		}
+		f.player(t, away, 5)
+	})
+}
+
+func TestPlayerServiceDelete(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	scorer := f.player(t, home, 9)
+	bench := f.player(t, home, 12)
+	match := f.match(t, home, away)
+	f.publish(t, match, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: scorer.ID, TeamID: home.ID, Minute: 30}}})
+
+	tests := []struct {
+		name     string
+		playerID uint
+		wantErr  string
+	}{
+		{"scored goals", scorer.ID, "has scored goals"},
+		{"no goals", bench.ID, ""},
+		{"already deleted", bench.ID, "player not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr(t, f.players.Delete(f.ctx, tt.playerID), tt.wantErr)
+		})
+	}
+}
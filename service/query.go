@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/repository"
+)
+
+// newQuery starts a repository query for the page p of a list sorted by the
+// sort parameter of the request.
+func newQuery[T any](schema repository.Schema[T], sort string, p dto.Pagination) (repository.Query, error) {
+	sorts, err := schema.ParseSort(sort)
+	if err != nil {
+		return repository.Query{}, ErrInvalidSort.Withf("%s", err)
+	}
+
+	q := repository.Query{
+		Sort:       sorts,
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		CountTotal: p.Cursor == "" || p.IncludeTotal,
+	}
+	if p.Cursor != "" {
+		if err := schema.DecodeCursor(p.Cursor, &q); err != nil {
+			return repository.Query{}, ErrInvalidCursor.Withf("%s", err)
+		}
+	}
+	return q, nil
+}
+
+// batchSize is the number of rows eachPage fetches at a time.
+const batchSize = 500
+
+// eachPage calls fn with every row matched by q, a batch at a time, walking
+// the list by cursor so that only one batch is held in memory.
+func eachPage[T any](ctx context.Context, schema repository.Schema[T], q repository.Query, find func(context.Context, repository.Query) (repository.Page[T], error), fn func([]T) error) error {
+	q.Page, q.PerPage, q.CountTotal = 1, batchSize, false
+	for {
+		page, err := find(ctx, q)
+		if err != nil {
+			return err
+		}
+		if err := fn(page.Items); err != nil {
+			return err
+		}
+		if page.Next == "" {
+			return nil
+		}
+		if err := schema.DecodeCursor(page.Next, &q); err != nil {
+			return err
+		}
+	}
+}
@@ -1,53 +1,72 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/pranotoism/football-go/dto"
 	"github.com/pranotoism/football-go/model"
 	"github.com/pranotoism/football-go/repository"
-	"gorm.io/gorm"
+	"github.com/pranotoism/football-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+const unknownName = "Unknown"
+
 type ReportService struct {
-	matchRepo *repository.MatchRepository
+	matchRepo MatchRepository
 }
 
-func NewReportService(matchRepo *repository.MatchRepository) *ReportService {
+func NewReportService(matchRepo MatchRepository) *ReportService {
 	return &ReportService{matchRepo: matchRepo}
 }
 
-func (s *ReportService) GetMatchReport(id uint) (*dto.MatchReport, error) {
-	match, err := s.matchRepo.FindByID(id)
+func (s *ReportService) GetMatchReport(ctx context.Context, id uint) (*dto.MatchReport, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetMatchReport")
+	defer span.End()
+
+	match, err := s.matchRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("match not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMatchNotFound
 		}
 		return nil, err
 	}
 
 	if match.HomeScore == nil {
-		return nil, errors.New("match result has not been reported yet")
+		return nil, ErrResultNotReported
 	}
 
-	return s.buildReport(match), nil
+	return s.buildReport(ctx, match), nil
 }
 
-func (s *ReportService) GetAllMatchReports(page, perPage int) ([]dto.MatchReport, int64, error) {
-	matches, total, err := s.matchRepo.FindPlayedMatches(page, perPage)
+func (s *ReportService) GetAllMatchReports(ctx context.Context, p dto.Pagination) (repository.Page[dto.MatchReport], error) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetAllMatchReports")
+	defer span.End()
+
+	q, err := newQuery(repository.MatchSchema, "", p)
 	if err != nil {
-		return nil, 0, err
+		return repository.Page[dto.MatchReport]{}, err
+	}
+	matches, err := s.matchRepo.FindPlayedMatches(ctx, q)
+	if err != nil {
+		return repository.Page[dto.MatchReport]{}, err
 	}
 
-	reports := make([]dto.MatchReport, len(matches))
-	for i := range matches {
-		reports[i] = *s.buildReport(&matches[i])
+	reports := make([]dto.MatchReport, len(matches.Items))
+	for i := range matches.Items {
+		reports[i] = *s.buildReport(ctx, &matches.Items[i])
 	}
 
-	return reports, total, nil
+	return repository.Page[dto.MatchReport]{Items: reports, Total: matches.Total, Next: matches.Next, Prev: matches.Prev}, nil
 }
 
-func (s *ReportService) buildReport(match *model.Match) *dto.MatchReport {
+// buildReport counts each team's wins with a query of its own, so reports
+// get a span per match to make that cost visible in traces.
+func (s *ReportService) buildReport(ctx context.Context, match *model.Match) *dto.MatchReport {
+	ctx, span := tracing.Start(ctx, "ReportService.buildReport", attribute.Int("match.id", int(match.ID)))
+	defer span.End()
+
 	status := "Draw"
 	if *match.HomeScore > *match.AwayScore {
 		status = "Home Win"
@@ -60,13 +79,22 @@ func (s *ReportService) buildReport(match *model.Match) *dto.MatchReport {
 	playerNames := make(map[uint]string)
 
 	for i, g := range match.Goals {
+		playerName := unknownName
+		if g.Player != nil {
+			playerName = g.Player.Name
+		}
+		teamName := unknownName
+		if g.Team != nil {
+			teamName = g.Team.Name
+		}
+
 		goals[i] = dto.GoalDetail{
-			PlayerName: g.Player.Name,
-			TeamName:   g.Team.Name,
+			PlayerName: playerName,
+			TeamName:   teamName,
 			Minute:     g.Minute,
 		}
 		playerGoalCount[g.PlayerID]++
-		playerNames[g.PlayerID] = g.Player.Name
+		playerNames[g.PlayerID] = playerName
 	}
 
 	var topScorer *dto.TopScorer
@@ -81,15 +109,19 @@ func (s *ReportService) buildReport(match *model.Match) *dto.MatchReport {
 		}
 	}
 
-	cumulativeHomeWins := s.matchRepo.CountWins(match.HomeTeamID)
-	cumulativeAwayWins := s.matchRepo.CountWins(match.AwayTeamID)
+	cumulativeHomeWins := s.matchRepo.CountWins(ctx, match.HomeTeamID)
+	cumulativeAwayWins := s.matchRepo.CountWins(ctx, match.AwayTeamID)
 
 	return &dto.MatchReport{
 		MatchID:            match.ID,
-		MatchDate:          match.MatchDate,
-		MatchTime:          match.MatchTime,
-		HomeTeam:           dto.TeamInfo{ID: match.HomeTeam.ID, Name: match.HomeTeam.Name},
-		AwayTeam:           dto.TeamInfo{ID: match.AwayTeam.ID, Name: match.AwayTeam.Name},
+		KickoffAt:          match.KickoffAt.UTC(),
+		KickoffLocal:       match.Kickoff(),
+		TimeZone:           match.TimeZone,
+		MatchDate:          match.MatchDate(),
+		MatchTime:          match.MatchTime(),
+		Competition:        match.Competition,
+		HomeTeam:           teamInfo(match.HomeTeamID, match.HomeTeam),
+		AwayTeam:           teamInfo(match.AwayTeamID, match.AwayTeam),
 		HomeScore:          *match.HomeScore,
 		AwayScore:          *match.AwayScore,
 		Status:             status,
@@ -99,3 +131,12 @@ func (s *ReportService) buildReport(match *model.Match) *dto.MatchReport {
 		CumulativeAwayWins: cumulativeAwayWins,
 	}
 }
+
+// teamInfo tolerates a missing team so historical matches still render
+// after their team has been purged.
+func teamInfo(id uint, team *model.Team) dto.TeamInfo {
+	if team == nil {
+		return dto.TeamInfo{ID: id, Name: unknownName}
+	}
+	return dto.TeamInfo{ID: team.ID, Name: team.Name, LogoURL: team.LogoURL, Deleted: team.DeletedAt.Valid}
+}
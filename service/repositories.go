@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+)
+
+// The interfaces below describe the storage the services depend on. They
+// are implemented by the GORM repositories in package repository and by the
+// in-memory store in package repository/memory used in tests. Lookups
+// return repository.ErrNotFound when nothing matches, and calls made with a
+// context from repository.Transactor.WithinTx share its transaction.
+
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) error
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	FindByEmailVerificationToken(ctx context.Context, token string) (*model.User, error)
+	Update(ctx context.Context, user *model.User) error
+	Delete(ctx context.Context, user *model.User) error
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *model.Team) error
+	FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Team], error)
+	FindByID(ctx context.Context, id uint) (*model.Team, error)
+	Update(ctx context.Context, team *model.Team) error
+	Delete(ctx context.Context, team *model.Team) error
+	Exists(ctx context.Context, id uint) bool
+	FindDeleted(ctx context.Context, page, perPage int) ([]model.Team, int64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*model.Team, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
+}
+
+type PlayerRepository interface {
+	Create(ctx context.Context, player *model.Player) error
+	FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Player], error)
+	FindByTeam(ctx context.Context, teamID uint, q repository.Query) (repository.Page[model.Player], error)
+	FindByID(ctx context.Context, id uint) (*model.Player, error)
+	Update(ctx context.Context, player *model.Player) error
+	Delete(ctx context.Context, player *model.Player) error
+	IsJerseyNumberTaken(ctx context.Context, teamID uint, jerseyNumber int, excludePlayerID uint) bool
+	SoftDeleteByTeamID(ctx context.Context, teamID uint) error
+	FindDeleted(ctx context.Context, page, perPage int) ([]model.Player, int64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*model.Player, error)
+	Restore(ctx context.Context, id uint) error
+	RestoreByTeamID(ctx context.Context, teamID uint, deletedAt time.Time) error
+	Purge(ctx context.Context, id uint) error
+	PurgeByTeamID(ctx context.Context, teamID uint) error
+}
+
+type MatchRepository interface {
+	Create(ctx context.Context, match *model.Match) error
+	FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Match], error)
+	FindByID(ctx context.Context, id uint) (*model.Match, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*model.Match, error)
+	Update(ctx context.Context, match *model.Match) error
+	UpdateScore(ctx context.Context, id uint, homeScore, awayScore int) error
+	Delete(ctx context.Context, match *model.Match) error
+	CountActiveByTeamID(ctx context.Context, teamID uint) int64
+	CountByTeamID(ctx context.Context, teamID uint) int64
+	SoftDeleteByTeamID(ctx context.Context, teamID uint) error
+	FindDeleted(ctx context.Context, page, perPage int) ([]model.Match, int64, error)
+	FindDeletedByID(ctx context.Context, id uint) (*model.Match, error)
+	FindDeletedByTeamIDAt(ctx context.Context, teamID uint, deletedAt time.Time) ([]model.Match, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
+	FindPlayedMatches(ctx context.Context, q repository.Query) (repository.Page[model.Match], error)
+	CountWins(ctx context.Context, teamID uint) int64
+}
+
+type GoalRepository interface {
+	CreateBatch(ctx context.Context, goals []model.Goal) error
+	FindAll(ctx context.Context, q repository.Query) (repository.Page[model.Goal], error)
+	DeleteByMatchID(ctx context.Context, matchID uint) error
+	DeleteByTeamMatches(ctx context.Context, teamID uint) error
+	FindDeleted(ctx context.Context, page, perPage int) ([]model.Goal, int64, error)
+	RestoreByMatchID(ctx context.Context, matchID uint, deletedAt time.Time) error
+	CountActiveByPlayerID(ctx context.Context, playerID uint) int64
+	CountByPlayerID(ctx context.Context, playerID uint) int64
+	PurgeByMatchID(ctx context.Context, matchID uint) error
+}
+
+type MatchRevisionRepository interface {
+	Create(ctx context.Context, revision *model.MatchResultRevision) error
+	NextRevision(ctx context.Context, matchID uint) (int, error)
+	FindByMatchID(ctx context.Context, matchID uint) ([]model.MatchResultRevision, error)
+}
+
+type ResultSubmissionRepository interface {
+	Create(ctx context.Context, submission *model.ResultSubmission) error
+	FindByID(ctx context.Context, id uint) (*model.ResultSubmission, error)
+	FindByMatchID(ctx context.Context, matchID uint) ([]model.ResultSubmission, error)
+	FindByStatus(ctx context.Context, status string, page, perPage int) ([]model.ResultSubmission, int64, error)
+	HasOpenSubmission(ctx context.Context, matchID uint) bool
+	Transition(ctx context.Context, submission *model.ResultSubmission, from string) error
+}
+
+type AuditRepository interface {
+	FindAll(ctx context.Context, filter repository.AuditFilter, page, perPage int) ([]model.AuditLog, int64, error)
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, q repository.SearchQuery) ([]repository.SearchHit, error)
+}
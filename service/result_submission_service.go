@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+// ResultSubmissionService handles the reporter/official workflow for match
+// results. A result only becomes visible on the match, in reports and in
+// win counts once an official approves it.
+type ResultSubmissionService struct {
+	submissionRepo ResultSubmissionRepository
+	matchService   *MatchService
+}
+
+func NewResultSubmissionService(submissionRepo ResultSubmissionRepository, matchService *MatchService) *ResultSubmissionService {
+	return &ResultSubmissionService{submissionRepo: submissionRepo, matchService: matchService}
+}
+
+func (s *ResultSubmissionService) Create(ctx context.Context, matchID, userID uint, req dto.ReportResultRequest) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Create")
+	defer span.End()
+
+	match, err := s.matchService.FindByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if match.HomeScore != nil {
+		return nil, ErrResultAlreadyReported
+	}
+	if s.submissionRepo.HasOpenSubmission(ctx, matchID) {
+		return nil, ErrSubmissionPending
+	}
+
+	if err := validateResult(match, req.HomeScore, req.AwayScore, req.Goals); err != nil {
+		return nil, err
+	}
+
+	goals, err := json.Marshal(nonNilGoals(req.Goals))
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &model.ResultSubmission{
+		MatchID:     matchID,
+		Status:      model.SubmissionDraft,
+		HomeScore:   req.HomeScore,
+		AwayScore:   req.AwayScore,
+		Goals:       string(goals),
+		SubmittedBy: userID,
+	}
+	if !req.Draft {
+		now := time.Now()
+		submission.Status = model.SubmissionSubmitted
+		submission.SubmittedAt = &now
+	}
+
+	if err := s.submissionRepo.Create(ctx, submission); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrSubmissionPending
+		}
+		return nil, err
+	}
+	return toSubmissionDTO(submission)
+}
+
+func (s *ResultSubmissionService) FindByID(ctx context.Context, id uint) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.FindByID")
+	defer span.End()
+
+	submission, err := s.findSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSubmissionDTO(submission)
+}
+
+func (s *ResultSubmissionService) FindByMatch(ctx context.Context, matchID uint) ([]dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.FindByMatch")
+	defer span.End()
+
+	if _, err := s.matchService.FindByID(ctx, matchID); err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.submissionRepo.FindByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	return toSubmissionDTOs(submissions)
+}
+
+func (s *ResultSubmissionService) FindByStatus(ctx context.Context, status string, page, perPage int) ([]dto.ResultSubmission, int64, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.FindByStatus")
+	defer span.End()
+
+	submissions, total, err := s.submissionRepo.FindByStatus(ctx, status, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := toSubmissionDTOs(submissions)
+	return result, total, err
+}
+
+func (s *ResultSubmissionService) Update(ctx context.Context, id, userID uint, req dto.UpdateSubmissionRequest) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Update")
+	defer span.End()
+
+	submission, err := s.findSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.SubmittedBy != userID {
+		return nil, ErrNotReporter
+	}
+	if submission.Status != model.SubmissionDraft && submission.Status != model.SubmissionRejected {
+		return nil, ErrSubmissionState.Withf("a %s submission cannot be changed", submission.Status)
+	}
+
+	match, err := s.matchService.FindByID(ctx, submission.MatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reopening a rejected submission must not race a result that has been
+	// published or proposed since; published results are amended instead.
+	if match.HomeScore != nil {
+		return nil, ErrResultAlreadyReported
+	}
+	if submission.Status == model.SubmissionRejected && s.submissionRepo.HasOpenSubmission(ctx, submission.MatchID) {
+		return nil, ErrSubmissionPending
+	}
+
+	if err := validateResult(match, req.HomeScore, req.AwayScore, req.Goals); err != nil {
+		return nil, err
+	}
+
+	goals, err := json.Marshal(nonNilGoals(req.Goals))
+	if err != nil {
+		return nil, err
+	}
+
+	from := submission.Status
+	submission.Status = model.SubmissionDraft
+	submission.HomeScore = req.HomeScore
+	submission.AwayScore = req.AwayScore
+	submission.Goals = string(goals)
+
+	if err := s.transition(ctx, submission, from); err != nil {
+		return nil, err
+	}
+	return toSubmissionDTO(submission)
+}
+
+func (s *ResultSubmissionService) Submit(ctx context.Context, id, userID uint) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Submit")
+	defer span.End()
+
+	submission, err := s.findSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.SubmittedBy != userID {
+		return nil, ErrNotReporter.Withf("only the reporter can submit this submission")
+	}
+	if submission.Status != model.SubmissionDraft {
+		return nil, ErrSubmissionState.Withf("a %s submission cannot be submitted", submission.Status)
+	}
+
+	now := time.Now()
+	submission.Status = model.SubmissionSubmitted
+	submission.SubmittedAt = &now
+
+	if err := s.transition(ctx, submission, model.SubmissionDraft); err != nil {
+		return nil, err
+	}
+	return toSubmissionDTO(submission)
+}
+
+// Approve publishes the submitted result on the match. Publishing and
+// marking the submission approved happen in the same transaction, with the
+// match locked. Only a match without a result can be approved; later
+// corrections go through MatchService.AmendResult, which records a reason.
+func (s *ResultSubmissionService) Approve(ctx context.Context, id, reviewerID uint, req dto.ReviewSubmissionRequest) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Approve")
+	defer span.End()
+
+	submission, err := s.findSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.Status != model.SubmissionSubmitted {
+		return nil, ErrSubmissionState.Withf("a %s submission cannot be approved", submission.Status)
+	}
+
+	var goals []dto.GoalInput
+	if err := json.Unmarshal([]byte(submission.Goals), &goals); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	submission.Status = model.SubmissionApproved
+	submission.ReviewedBy = &reviewerID
+	submission.ReviewedAt = &now
+	submission.ReviewNote = req.Note
+
+	reason := fmt.Sprintf("approved result submission #%d", submission.ID)
+	err = s.matchService.publishResult(ctx, submission.MatchID, reviewerID, reason, submission.HomeScore, submission.AwayScore, goals, func(ctx context.Context, match *model.Match) error {
+		if match.HomeScore != nil {
+			return ErrSubmissionState.Withf("match %d already has a published result; amend it instead", match.ID)
+		}
+		return s.transition(ctx, submission, model.SubmissionSubmitted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissionDTO(submission)
+}
+
+func (s *ResultSubmissionService) Reject(ctx context.Context, id, reviewerID uint, req dto.ReviewSubmissionRequest) (*dto.ResultSubmission, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Reject")
+	defer span.End()
+
+	submission, err := s.findSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.Status != model.SubmissionSubmitted {
+		return nil, ErrSubmissionState.Withf("a %s submission cannot be rejected", submission.Status)
+	}
+	if req.Note == "" {
+		return nil, ErrRejectionNoteRequired
+	}
+
+	now := time.Now()
+	submission.Status = model.SubmissionRejected
+	submission.ReviewedBy = &reviewerID
+	submission.ReviewedAt = &now
+	submission.ReviewNote = req.Note
+
+	if err := s.transition(ctx, submission, model.SubmissionSubmitted); err != nil {
+		return nil, err
+	}
+	return toSubmissionDTO(submission)
+}
+
+// Diff compares a submission with the result currently published on the
+// match. Published is nil when the match has no approved result yet.
+func (s *ResultSubmissionService) Diff(ctx context.Context, id uint) (*dto.SubmissionDiff, error) {
+	ctx, span := tracing.Start(ctx, "ResultSubmissionService.Diff")
+	defer span.End()
+
+	submission, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.matchService.FindByID(ctx, submission.MatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &dto.SubmissionDiff{
+		SubmissionID: submission.ID,
+		MatchID:      submission.MatchID,
+		Submitted: dto.ResultData{
+			HomeScore: submission.HomeScore,
+			AwayScore: submission.AwayScore,
+			Goals:     submission.Goals,
+		},
+		ScoreChanged: true,
+		GoalsAdded:   submission.Goals,
+		GoalsRemoved: []dto.GoalInput{},
+	}
+
+	if match.HomeScore == nil {
+		return diff, nil
+	}
+
+	published := make([]dto.GoalInput, len(match.Goals))
+	for i, g := range match.Goals {
+		published[i] = dto.GoalInput{PlayerID: g.PlayerID, TeamID: g.TeamID, Minute: g.Minute}
+	}
+
+	diff.Published = &dto.ResultData{
+		HomeScore: *match.HomeScore,
+		AwayScore: *match.AwayScore,
+		Goals:     published,
+	}
+	diff.ScoreChanged = *match.HomeScore != submission.HomeScore || *match.AwayScore != submission.AwayScore
+	diff.GoalsAdded = subtractGoals(submission.Goals, published)
+	diff.GoalsRemoved = subtractGoals(published, submission.Goals)
+
+	return diff, nil
+}
+
+func (s *ResultSubmissionService) findSubmission(ctx context.Context, id uint) (*model.ResultSubmission, error) {
+	submission, err := s.submissionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	return submission, nil
+}
+
+// transition saves submission unless another request has moved it out of
+// the status from since it was read.
+func (s *ResultSubmissionService) transition(ctx context.Context, submission *model.ResultSubmission, from string) error {
+	err := s.submissionRepo.Transition(ctx, submission, from)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrSubmissionState.Withf("the submission is no longer %s", from)
+	case errors.Is(err, repository.ErrDuplicate):
+		return ErrSubmissionPending
+	}
+	return err
+}
+
+// subtractGoals returns the goals in a that have no matching goal in b,
+// treating identical goals as a multiset.
+func subtractGoals(a, b []dto.GoalInput) []dto.GoalInput {
+	remaining := make(map[dto.GoalInput]int)
+	for _, g := range b {
+		remaining[g]++
+	}
+
+	result := []dto.GoalInput{}
+	for _, g := range a {
+		if remaining[g] > 0 {
+			remaining[g]--
+			continue
+		}
+		result = append(result, g)
+	}
+	return result
+}
+
+func nonNilGoals(goals []dto.GoalInput) []dto.GoalInput {
+	if goals == nil {
+		return []dto.GoalInput{}
+	}
+	return goals
+}
+
+func toSubmissionDTO(submission *model.ResultSubmission) (*dto.ResultSubmission, error) {
+	var goals []dto.GoalInput
+	if err := json.Unmarshal([]byte(submission.Goals), &goals); err != nil {
+		return nil, err
+	}
+
+	return &dto.ResultSubmission{
+		ID:          submission.ID,
+		MatchID:     submission.MatchID,
+		Status:      submission.Status,
+		HomeScore:   submission.HomeScore,
+		AwayScore:   submission.AwayScore,
+		Goals:       goals,
+		SubmittedBy: submission.SubmittedBy,
+		SubmittedAt: submission.SubmittedAt,
+		ReviewedBy:  submission.ReviewedBy,
+		ReviewedAt:  submission.ReviewedAt,
+		ReviewNote:  submission.ReviewNote,
+		CreatedAt:   submission.CreatedAt,
+		UpdatedAt:   submission.UpdatedAt,
+	}, nil
+}
+
+func toSubmissionDTOs(submissions []model.ResultSubmission) ([]dto.ResultSubmission, error) {
+	result := make([]dto.ResultSubmission, len(submissions))
+	for i := range submissions {
+		item, err := toSubmissionDTO(&submissions[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *item
+	}
+	return result, nil
+}
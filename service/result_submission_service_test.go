@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestResultSubmissionServiceCreate(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	homePlayer := f.player(t, home, 9)
+	awayPlayer := f.player(t, away, 9)
+	outsider := f.team(t, "Outsider")
+
+	homeGoal := dto.GoalInput{PlayerID: homePlayer.ID, TeamID: home.ID, Minute: 12}
+	awayGoal := dto.GoalInput{PlayerID: awayPlayer.ID, TeamID: away.ID, Minute: 70}
+
+	tests := []struct {
+		name    string
+		req     dto.ReportResultRequest
+		wantErr string
+	}{
+		{"home goals short", dto.ReportResultRequest{HomeScore: 2, AwayScore: 1, Goals: []dto.GoalInput{homeGoal, awayGoal}}, "home goal count (1) does not match home_score (2)"},
+		{"away goals extra", dto.ReportResultRequest{HomeScore: 1, AwayScore: 0, Goals: []dto.GoalInput{homeGoal, awayGoal}}, "away goal count (1) does not match away_score (0)"},
+		{"goal for a team not playing", dto.ReportResultRequest{HomeScore: 0, AwayScore: 0, Goals: []dto.GoalInput{{PlayerID: homePlayer.ID, TeamID: outsider.ID, Minute: 5}}}, "does not belong to either team"},
+		{"goalless draw", dto.ReportResultRequest{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := f.match(t, home, away)
+			_, err := f.submissions.Create(f.ctx, match.ID, 1, tt.req)
+			checkErr(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResultSubmissionServiceDoubleReporting(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	result := dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 44}}}
+
+	open := f.match(t, home, away)
+	if _, err := f.submissions.Create(f.ctx, open.ID, 1, dto.ReportResultRequest{Draft: true}); err != nil {
+		t.Fatal(err)
+	}
+	published := f.match(t, home, away)
+	f.publish(t, published, result)
+	rejected := f.match(t, home, away)
+	submission, err := f.submissions.Create(f.ctx, rejected.ID, 1, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.submissions.Reject(f.ctx, submission.ID, 2, dto.ReviewSubmissionRequest{Note: "wrong scorer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		matchID uint
+		wantErr string
+	}{
+		{"open submission", open.ID, "already has a pending result submission"},
+		{"already published", published.ID, "match result already reported"},
+		{"after rejection", rejected.ID, ""},
+		{"unknown match", 99, "match not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.submissions.Create(f.ctx, tt.matchID, 1, result)
+			checkErr(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResultSubmissionServiceWorkflow(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	match := f.match(t, home, away)
+
+	draft, err := f.submissions.Create(f.ctx, match.ID, 1, dto.ReportResultRequest{Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name    string
+		run     func() (*dto.ResultSubmission, error)
+		wantErr string
+		status  string
+	}{
+		{"approve draft", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Approve(f.ctx, draft.ID, 2, dto.ReviewSubmissionRequest{})
+		}, "a draft submission cannot be approved", ""},
+		{"update by another user", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Update(f.ctx, draft.ID, 3, dto.UpdateSubmissionRequest{})
+		}, "only the reporter", ""},
+		{"update with mismatch", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Update(f.ctx, draft.ID, 1, dto.UpdateSubmissionRequest{HomeScore: 1})
+		}, "home goal count (0) does not match home_score (1)", ""},
+		{"update", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Update(f.ctx, draft.ID, 1, dto.UpdateSubmissionRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 3}}})
+		}, "", model.SubmissionDraft},
+		{"submit", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Submit(f.ctx, draft.ID, 1)
+		}, "", model.SubmissionSubmitted},
+		{"reject without note", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Reject(f.ctx, draft.ID, 2, dto.ReviewSubmissionRequest{})
+		}, "a note is required", ""},
+		{"approve", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Approve(f.ctx, draft.ID, 2, dto.ReviewSubmissionRequest{Note: "ok"})
+		}, "", model.SubmissionApproved},
+		{"approve twice", func() (*dto.ResultSubmission, error) {
+			return f.submissions.Approve(f.ctx, draft.ID, 2, dto.ReviewSubmissionRequest{})
+		}, "approved submission cannot be approved", ""},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			submission, err := step.run()
+			checkErr(t, err, step.wantErr)
+			if err == nil && submission.Status != step.status {
+				t.Errorf("status = %q, want %q", submission.Status, step.status)
+			}
+		})
+	}
+
+	published, err := f.matches.FindByID(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.HomeScore == nil || *published.HomeScore != 1 || len(published.Goals) != 1 {
+		t.Errorf("approved result not published on the match: %+v", published)
+	}
+}
+
+func TestResultSubmissionServiceReopenRejected(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	result := dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 44}}}
+	revision := dto.UpdateSubmissionRequest{HomeScore: 1, Goals: result.Goals}
+
+	// reject creates a match with a rejected submission by reporter 1.
+	reject := func(t *testing.T) (*model.Match, *dto.ResultSubmission) {
+		t.Helper()
+		match := f.match(t, home, away)
+		submission, err := f.submissions.Create(f.ctx, match.ID, 1, result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.submissions.Reject(f.ctx, submission.ID, 2, dto.ReviewSubmissionRequest{Note: "wrong minute"}); err != nil {
+			t.Fatal(err)
+		}
+		return match, submission
+	}
+
+	t.Run("after another submission was approved", func(t *testing.T) {
+		match, old := reject(t)
+		f.publish(t, match, result)
+
+		_, err := f.submissions.Update(f.ctx, old.ID, 1, revision)
+		if !errors.Is(err, service.ErrResultAlreadyReported) {
+			t.Errorf("Update() error = %v, want %v", err, service.ErrResultAlreadyReported)
+		}
+	})
+
+	t.Run("while another submission is open", func(t *testing.T) {
+		match, old := reject(t)
+		if _, err := f.submissions.Create(f.ctx, match.ID, 3, result); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := f.submissions.Update(f.ctx, old.ID, 1, revision)
+		if !errors.Is(err, service.ErrSubmissionPending) {
+			t.Errorf("Update() error = %v, want %v", err, service.ErrSubmissionPending)
+		}
+	})
+
+	t.Run("with nothing else open", func(t *testing.T) {
+		_, old := reject(t)
+		reopened, err := f.submissions.Update(f.ctx, old.ID, 1, revision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reopened.Status != model.SubmissionDraft {
+			t.Errorf("status = %q, want %q", reopened.Status, model.SubmissionDraft)
+		}
+	})
+}
+
+func TestResultSubmissionServiceApprovePublishedMatch(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	striker := f.player(t, home, 9)
+	match := f.match(t, home, away)
+
+	first, err := f.submissions.Create(f.ctx, match.ID, 1, dto.ReportResultRequest{HomeScore: 1, Goals: []dto.GoalInput{{PlayerID: striker.ID, TeamID: home.ID, Minute: 44}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A second open submission for the same match is refused outright
+	now := time.Now()
+	second := &model.ResultSubmission{MatchID: match.ID, Status: model.SubmissionSubmitted, Goals: "[]", SubmittedBy: 3, SubmittedAt: &now}
+	if err := f.store.Submissions().Create(f.ctx, second); !errors.Is(err, repository.ErrDuplicate) {
+		t.Fatalf("Create() error = %v, want %v", err, repository.ErrDuplicate)
+	}
+
+	if _, err := f.submissions.Approve(f.ctx, first.ID, 2, dto.ReviewSubmissionRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.submissions.Approve(f.ctx, first.ID, 2, dto.ReviewSubmissionRequest{})
+	if !errors.Is(err, service.ErrSubmissionState) {
+		t.Fatalf("second Approve() error = %v, want %v", err, service.ErrSubmissionState)
+	}
+
+	// One that was opened once the first was approved, as a race between
+	// approving and reporting leaves it, still cannot overwrite the result
+	if err := f.store.Submissions().Create(f.ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.submissions.Approve(f.ctx, second.ID, 2, dto.ReviewSubmissionRequest{})
+	if !errors.Is(err, service.ErrSubmissionState) {
+		t.Fatalf("Approve() error = %v, want %v", err, service.ErrSubmissionState)
+	}
+
+	published, err := f.matches.FindByID(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *published.HomeScore != 1 || *published.AwayScore != 0 || len(published.Goals) != 1 {
+		t.Errorf("published result = %d-%d with %d goals, want the first approval to stand at 1-0 with 1 goal", *published.HomeScore, *published.AwayScore, len(published.Goals))
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+const defaultSearchLimit = 20
+
+type SearchService struct {
+	searchRepo SearchRepository
+}
+
+func NewSearchService(searchRepo SearchRepository) *SearchService {
+	return &SearchService{searchRepo: searchRepo}
+}
+
+// Search ranks the teams, players and venues matching query.Q. query.Type
+// restricts the results to a comma-separated list of types.
+func (s *SearchService) Search(ctx context.Context, query dto.SearchQuery) ([]dto.SearchResult, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer span.End()
+
+	q := repository.SearchQuery{Text: strings.TrimSpace(query.Q), Limit: query.Limit}
+	if utf8.RuneCountInString(q.Text) < 2 {
+		return nil, ErrSearchTooShort
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultSearchLimit
+	}
+	if query.Type != "" {
+		for _, t := range strings.Split(query.Type, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(repository.SearchTypes, t) {
+				return nil, ErrInvalidSearchType.Withf("cannot search for %q, types are %s", t, strings.Join(repository.SearchTypes, ", "))
+			}
+			if !slices.Contains(q.Types, t) {
+				q.Types = append(q.Types, t)
+			}
+		}
+	}
+
+	hits, err := s.searchRepo.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = dto.SearchResult{
+			Type:   hit.Type,
+			ID:     hit.ID,
+			Name:   hit.Name,
+			Detail: hit.Detail,
+			TeamID: hit.TeamID,
+			Score:  hit.Score,
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/service"
+)
+
+type searchRepoFunc func(q repository.SearchQuery) []repository.SearchHit
+
+func (f searchRepoFunc) Search(_ context.Context, q repository.SearchQuery) ([]repository.SearchHit, error) {
+	return f(q), nil
+}
+
+func TestSearchService(t *testing.T) {
+	var got repository.SearchQuery
+	svc := service.NewSearchService(searchRepoFunc(func(q repository.SearchQuery) []repository.SearchHit {
+		got = q
+		return []repository.SearchHit{{Type: "team", ID: 1, Name: "Persib", TeamID: 1, Score: 1}}
+	}))
+
+	results, err := svc.Search(context.Background(), dto.SearchQuery{Q: "  persib ", Type: "player, team,player"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "persib" || got.Limit != 20 || !slices.Equal(got.Types, []string{"player", "team"}) {
+		t.Errorf("repository queried with %+v", got)
+	}
+	if len(results) != 1 || results[0].Name != "Persib" {
+		t.Errorf("results = %+v", results)
+	}
+
+	tests := []struct {
+		name  string
+		query dto.SearchQuery
+		want  error
+	}{
+		{"too short", dto.SearchQuery{Q: " é "}, service.ErrSearchTooShort},
+		{"unknown type", dto.SearchQuery{Q: "persib", Type: "team,coach"}, service.ErrInvalidSearchType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Search(context.Background(), tt.query); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository/memory"
+	"github.com/pranotoism/football-go/service"
+)
+
+var (
+	_ service.UserRepository             = (*memory.UserRepository)(nil)
+	_ service.TeamRepository             = (*memory.TeamRepository)(nil)
+	_ service.PlayerRepository           = (*memory.PlayerRepository)(nil)
+	_ service.MatchRepository            = (*memory.MatchRepository)(nil)
+	_ service.GoalRepository             = (*memory.GoalRepository)(nil)
+	_ service.MatchRevisionRepository    = (*memory.MatchRevisionRepository)(nil)
+	_ service.ResultSubmissionRepository = (*memory.ResultSubmissionRepository)(nil)
+)
+
+// fixture wires the services to an in-memory store.
+type fixture struct {
+	ctx         context.Context
+	store       *memory.Store
+	teams       *service.TeamService
+	players     *service.PlayerService
+	matches     *service.MatchService
+	submissions *service.ResultSubmissionService
+	trash       *service.TrashService
+	// loc is the time zone matches are scheduled in by default.
+	loc *time.Location
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := memory.NewStore()
+	matches := service.NewMatchService(store, store.Matches(), store.Teams(), store.Goals(), store.Revisions(), loc)
+	return &fixture{
+		ctx:         context.Background(),
+		store:       store,
+		teams:       service.NewTeamService(store, store.Teams(), store.Players(), store.Matches(), store.Goals()),
+		players:     service.NewPlayerService(store.Players(), store.Teams(), store.Goals()),
+		matches:     matches,
+		submissions: service.NewResultSubmissionService(store.Submissions(), matches),
+		trash:       service.NewTrashService(store, store.Teams(), store.Players(), store.Matches(), store.Goals(), 0),
+		loc:         loc,
+	}
+}
+
+func (f *fixture) team(t *testing.T, name string) *model.Team {
+	t.Helper()
+	team, err := f.teams.Create(f.ctx, dto.CreateTeamRequest{Name: name, FoundedYear: 1990})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return team
+}
+
+func (f *fixture) player(t *testing.T, team *model.Team, jersey int) *model.Player {
+	t.Helper()
+	player, err := f.players.Create(f.ctx, team.ID, dto.CreatePlayerRequest{Name: "Player", Position: "penyerang", JerseyNumber: jersey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return player
+}
+
+func (f *fixture) match(t *testing.T, home, away *model.Team) *model.Match {
+	t.Helper()
+	match, err := f.matches.Create(f.ctx, dto.CreateMatchRequest{MatchDate: "2026-05-10", MatchTime: "19:00", HomeTeamID: home.ID, AwayTeamID: away.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return match
+}
+
+// publish reports a result and has an official approve it.
+func (f *fixture) publish(t *testing.T, match *model.Match, req dto.ReportResultRequest) {
+	t.Helper()
+	submission, err := f.submissions.Create(f.ctx, match.ID, 1, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.submissions.Approve(f.ctx, submission.ID, 2, dto.ReviewSubmissionRequest{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkErr fails the test unless err matches want, where an empty want
+// means no error and anything else must appear in the error message.
+func checkErr(t *testing.T, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case want != "" && err == nil:
+		t.Fatalf("expected error containing %q, got nil", want)
+	case want != "" && !strings.Contains(err.Error(), want):
+		t.Fatalf("expected error containing %q, got %q", want, err.Error())
+	}
+}
+
+func TestTransactionRollsBack(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	match := f.match(t, home, away)
+
+	err := f.store.WithinTx(f.ctx, func(ctx context.Context) error {
+		if err := f.store.Matches().UpdateScore(ctx, match.ID, 3, 0); err != nil {
+			return err
+		}
+		return context.DeadlineExceeded
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("WithinTx returned %v", err)
+	}
+
+	got, err := f.matches.FindByID(f.ctx, match.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.HomeScore != nil {
+		t.Errorf("score %d kept after rollback", *got.HomeScore)
+	}
+}
+
+func TestTrashRestoresCascadeTogether(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	f.player(t, home, 9)
+	early := f.player(t, home, 10)
+	match := f.match(t, home, away)
+
+	if err := f.players.Delete(f.ctx, early.ID); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := f.teams.Delete(f.ctx, home.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.matches.FindByID(f.ctx, match.ID); err == nil {
+		t.Fatal("match still visible after cascading team deletion")
+	}
+
+	if err := f.trash.RestoreTeam(f.ctx, home.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.matches.FindByID(f.ctx, match.ID); err != nil {
+		t.Errorf("match not restored with its team: %v", err)
+	}
+	players, err := f.players.FindByTeam(f.ctx, home.ID, dto.PlayerListQuery{}, dto.Pagination{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players.Items) != 1 || players.Items[0].JerseyNumber != 9 {
+		t.Errorf("restored players = %+v, want only #9", players.Items)
+	}
+}
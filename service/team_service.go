@@ -1,24 +1,31 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/pranotoism/football-go/dto"
 	"github.com/pranotoism/football-go/model"
 	"github.com/pranotoism/football-go/repository"
-	"gorm.io/gorm"
+	"github.com/pranotoism/football-go/tracing"
 )
 
 type TeamService struct {
-	teamRepo   *repository.TeamRepository
-	playerRepo *repository.PlayerRepository
+	tx         repository.Transactor
+	teamRepo   TeamRepository
+	playerRepo PlayerRepository
+	matchRepo  MatchRepository
+	goalRepo   GoalRepository
 }
 
-func NewTeamService(teamRepo *repository.TeamRepository, playerRepo *repository.PlayerRepository) *TeamService {
-	return &TeamService{teamRepo: teamRepo, playerRepo: playerRepo}
+func NewTeamService(tx repository.Transactor, teamRepo TeamRepository, playerRepo PlayerRepository, matchRepo MatchRepository, goalRepo GoalRepository) *TeamService {
+	return &TeamService{tx: tx, teamRepo: teamRepo, playerRepo: playerRepo, matchRepo: matchRepo, goalRepo: goalRepo}
 }
 
-func (s *TeamService) Create(req dto.CreateTeamRequest) (*model.Team, error) {
+func (s *TeamService) Create(ctx context.Context, req dto.CreateTeamRequest) (*model.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.Create")
+	defer span.End()
+
 	team := &model.Team{
 		Name:        req.Name,
 		LogoURL:     req.LogoURL,
@@ -27,32 +34,61 @@ func (s *TeamService) Create(req dto.CreateTeamRequest) (*model.Team, error) {
 		HQCity:      req.HQCity,
 	}
 
-	if err := s.teamRepo.Create(team); err != nil {
+	if err := s.teamRepo.Create(ctx, team); err != nil {
 		return nil, err
 	}
 	return team, nil
 }
 
-func (s *TeamService) FindAll(page, perPage int) ([]model.Team, int64, error) {
-	return s.teamRepo.FindAll(page, perPage)
+func (s *TeamService) FindAll(ctx context.Context, filter dto.TeamListQuery, p dto.Pagination) (repository.Page[model.Team], error) {
+	ctx, span := tracing.Start(ctx, "TeamService.FindAll")
+	defer span.End()
+
+	q, err := newQuery(repository.TeamSchema, filter.Sort, p)
+	if err != nil {
+		return repository.Page[model.Team]{}, err
+	}
+	if filter.Name != "" {
+		q.Where(repository.OpContains, filter.Name, "name")
+	}
+	if filter.City != "" {
+		q.Where(repository.OpContains, filter.City, "city")
+	}
+	if filter.FoundedFrom != 0 {
+		q.Where(repository.OpGte, filter.FoundedFrom, "founded_year")
+	}
+	if filter.FoundedTo != 0 {
+		q.Where(repository.OpLte, filter.FoundedTo, "founded_year")
+	}
+	if filter.FoundedFrom != 0 && filter.FoundedTo != 0 && filter.FoundedTo < filter.FoundedFrom {
+		return repository.Page[model.Team]{}, ErrInvalidRange.Withf("founded_to must not be before founded_from")
+	}
+
+	return s.teamRepo.FindAll(ctx, q)
 }
 
-func (s *TeamService) FindByID(id uint) (*model.Team, error) {
-	team, err := s.teamRepo.FindByID(id)
+func (s *TeamService) FindByID(ctx context.Context, id uint) (*model.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.FindByID")
+	defer span.End()
+
+	team, err := s.teamRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("team not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
 		}
 		return nil, err
 	}
 	return team, nil
 }
 
-func (s *TeamService) Update(id uint, req dto.UpdateTeamRequest) (*model.Team, error) {
-	team, err := s.teamRepo.FindByID(id)
+func (s *TeamService) Update(ctx context.Context, id uint, req dto.UpdateTeamRequest) (*model.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.Update")
+	defer span.End()
+
+	team, err := s.teamRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("team not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
 		}
 		return nil, err
 	}
@@ -73,25 +109,45 @@ func (s *TeamService) Update(id uint, req dto.UpdateTeamRequest) (*model.Team, e
 		team.HQCity = req.HQCity
 	}
 
-	if err := s.teamRepo.Update(team); err != nil {
+	if err := s.teamRepo.Update(ctx, team); err != nil {
 		return nil, err
 	}
 	return team, nil
 }
 
-func (s *TeamService) Delete(id uint) error {
-	team, err := s.teamRepo.FindByID(id)
+// Delete soft-deletes a team and its players. A team that still plays in
+// matches is only deleted when cascade is set, in which case those matches
+// and their goals are deleted with it.
+func (s *TeamService) Delete(ctx context.Context, id uint, cascade bool) error {
+	ctx, span := tracing.Start(ctx, "TeamService.Delete")
+	defer span.End()
+
+	team, err := s.teamRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("team not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTeamNotFound
 		}
 		return err
 	}
 
-	// Cascade soft-delete players
-	if err := s.playerRepo.SoftDeleteByTeamID(id); err != nil {
-		return err
+	matchCount := s.matchRepo.CountActiveByTeamID(ctx, id)
+	if matchCount > 0 && !cascade {
+		return ErrTeamHasMatches.Withf("team is referenced by %d matches, delete them first or pass cascade=true", matchCount)
 	}
 
-	return s.teamRepo.Delete(team)
+	// Cascade soft-delete matches, goals and players
+	return deletionTx(ctx, s.tx, func(ctx context.Context) error {
+		if matchCount > 0 {
+			if err := s.goalRepo.DeleteByTeamMatches(ctx, id); err != nil {
+				return err
+			}
+			if err := s.matchRepo.SoftDeleteByTeamID(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := s.playerRepo.SoftDeleteByTeamID(ctx, id); err != nil {
+			return err
+		}
+		return s.teamRepo.Delete(ctx, team)
+	})
 }
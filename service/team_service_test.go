@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/service"
+)
+
+func TestTeamServiceDelete(t *testing.T) {
+	f := newFixture(t)
+	home := f.team(t, "Home")
+	away := f.team(t, "Away")
+	idle := f.team(t, "Idle")
+	f.match(t, home, away)
+
+	tests := []struct {
+		name    string
+		teamID  uint
+		cascade bool
+		wantErr string
+	}{
+		{"referenced by matches", home.ID, false, "referenced by 1 matches"},
+		{"cascade", home.ID, true, ""},
+		{"no matches left", away.ID, false, ""},
+		{"no matches", idle.ID, false, ""},
+		{"unknown", 99, false, "team not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr(t, f.teams.Delete(f.ctx, tt.teamID, tt.cascade), tt.wantErr)
+		})
+	}
+}
+
+func TestTeamServiceFindAllFilters(t *testing.T) {
+	f := newFixture(t)
+	f.team(t, "Persib")
+	f.team(t, "Persija")
+	f.team(t, "Arema")
+
+	teams, err := f.teams.FindAll(f.ctx, dto.TeamListQuery{Name: "pers", Sort: "-name"}, dto.Pagination{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *teams.Total != 2 || teams.Items[0].Name != "Persija" || teams.Items[1].Name != "Persib" {
+		t.Fatalf("expected Persija then Persib, got %+v", teams.Items)
+	}
+
+	tests := []struct {
+		name   string
+		filter dto.TeamListQuery
+		want   error
+	}{
+		{"unknown sort field", dto.TeamListQuery{Sort: "hq_address"}, service.ErrInvalidSort},
+		{"inverted range", dto.TeamListQuery{FoundedFrom: 2000, FoundedTo: 1990}, service.ErrInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.teams.FindAll(f.ctx, tt.filter, dto.Pagination{Page: 1, PerPage: 10}); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTeamServiceFindAllByCursor(t *testing.T) {
+	f := newFixture(t)
+	for _, name := range []string{"Arema", "Bali United", "Persib", "Persija", "Semen Padang"} {
+		f.team(t, name)
+	}
+
+	filter := dto.TeamListQuery{Sort: "name"}
+	first, err := f.teams.FindAll(f.ctx, filter, dto.Pagination{Page: 1, PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Next == "" || first.Prev != "" {
+		t.Fatalf("first page links: next %q, prev %q", first.Next, first.Prev)
+	}
+
+	second, err := f.teams.FindAll(f.ctx, filter, dto.Pagination{PerPage: 2, Cursor: first.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Total != nil {
+		t.Errorf("cursor page counted without include_total: %d", *second.Total)
+	}
+	if names := teamNames(second.Items); names != "Persib,Persija" {
+		t.Fatalf("second page = %s, want Persib,Persija", names)
+	}
+
+	back, err := f.teams.FindAll(f.ctx, filter, dto.Pagination{PerPage: 2, Cursor: second.Prev, IncludeTotal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := teamNames(back.Items); names != "Arema,Bali United" || back.Prev != "" || *back.Total != 5 {
+		t.Fatalf("previous page = %s (prev %q), want Arema,Bali United", names, back.Prev)
+	}
+
+	last, err := f.teams.FindAll(f.ctx, filter, dto.Pagination{PerPage: 2, Cursor: second.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := teamNames(last.Items); names != "Semen Padang" || last.Next != "" {
+		t.Fatalf("last page = %s (next %q), want Semen Padang", names, last.Next)
+	}
+
+	tests := []struct {
+		name   string
+		filter dto.TeamListQuery
+		cursor string
+	}{
+		{"garbage", filter, "not-a-cursor"},
+		{"different sort", dto.TeamListQuery{Sort: "-name"}, first.Next},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.teams.FindAll(f.ctx, tt.filter, dto.Pagination{PerPage: 2, Cursor: tt.cursor})
+			if !errors.Is(err, service.ErrInvalidCursor) {
+				t.Fatalf("expected %v, got %v", service.ErrInvalidCursor, err)
+			}
+		})
+	}
+}
+
+func teamNames(teams []model.Team) string {
+	names := make([]string, len(teams))
+	for i, team := range teams {
+		names[i] = team.Name
+	}
+	return strings.Join(names, ",")
+}
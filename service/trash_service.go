@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+)
+
+// deletionTx runs fn in a transaction where every soft delete stamps the
+// same deleted_at. Rows removed by one cascading delete can then be told
+// apart from rows deleted earlier and restored together.
+func deletionTx(ctx context.Context, tx repository.Transactor, fn func(ctx context.Context) error) error {
+	return tx.WithinTx(repository.WithDeletionTime(ctx, time.Now()), fn)
+}
+
+// TrashService lists, restores and permanently purges soft-deleted records.
+// A record can only be purged once it has been in the trash for at least
+// the configured retention period.
+type TrashService struct {
+	tx         repository.Transactor
+	teamRepo   TeamRepository
+	playerRepo PlayerRepository
+	matchRepo  MatchRepository
+	goalRepo   GoalRepository
+	retention  time.Duration
+}
+
+func NewTrashService(tx repository.Transactor, teamRepo TeamRepository, playerRepo PlayerRepository, matchRepo MatchRepository, goalRepo GoalRepository, retention time.Duration) *TrashService {
+	return &TrashService{tx: tx, teamRepo: teamRepo, playerRepo: playerRepo, matchRepo: matchRepo, goalRepo: goalRepo, retention: retention}
+}
+
+func (s *TrashService) FindTeams(ctx context.Context, page, perPage int) ([]model.Team, int64, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.FindTeams")
+	defer span.End()
+
+	return s.teamRepo.FindDeleted(ctx, page, perPage)
+}
+
+func (s *TrashService) FindPlayers(ctx context.Context, page, perPage int) ([]model.Player, int64, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.FindPlayers")
+	defer span.End()
+
+	return s.playerRepo.FindDeleted(ctx, page, perPage)
+}
+
+func (s *TrashService) FindMatches(ctx context.Context, page, perPage int) ([]model.Match, int64, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.FindMatches")
+	defer span.End()
+
+	return s.matchRepo.FindDeleted(ctx, page, perPage)
+}
+
+func (s *TrashService) FindGoals(ctx context.Context, page, perPage int) ([]model.Goal, int64, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.FindGoals")
+	defer span.End()
+
+	return s.goalRepo.FindDeleted(ctx, page, perPage)
+}
+
+// RestoreTeam restores a team together with the players, matches and goals
+// that were deleted by the same operation. Records deleted individually
+// before the team stay in the trash, as do matches whose opponent is still
+// deleted.
+func (s *TrashService) RestoreTeam(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.RestoreTeam")
+	defer span.End()
+
+	team, err := s.teamRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedTeamNotFound
+		}
+		return err
+	}
+
+	deletedAt := team.DeletedAt.Time
+	matches, err := s.matchRepo.FindDeletedByTeamIDAt(ctx, id, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	var restorable []uint
+	for _, m := range matches {
+		opponent := m.AwayTeamID
+		if opponent == id {
+			opponent = m.HomeTeamID
+		}
+		if s.teamRepo.Exists(ctx, opponent) {
+			restorable = append(restorable, m.ID)
+		}
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if err := s.playerRepo.RestoreByTeamID(ctx, id, deletedAt); err != nil {
+			return err
+		}
+		for _, matchID := range restorable {
+			if err := s.goalRepo.RestoreByMatchID(ctx, matchID, deletedAt); err != nil {
+				return err
+			}
+			if err := s.matchRepo.Restore(ctx, matchID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *TrashService) RestorePlayer(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.RestorePlayer")
+	defer span.End()
+
+	player, err := s.playerRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedPlayerNotFound
+		}
+		return err
+	}
+
+	if !s.teamRepo.Exists(ctx, player.TeamID) {
+		return ErrTeamDeleted.Withf("the player's team is deleted, restore the team first")
+	}
+	if s.playerRepo.IsJerseyNumberTaken(ctx, player.TeamID, player.JerseyNumber, player.ID) {
+		return ErrJerseyNumberTaken
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.playerRepo.Restore(ctx, id)
+	})
+}
+
+// RestoreMatch restores a match together with the goals that were deleted
+// with it.
+func (s *TrashService) RestoreMatch(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.RestoreMatch")
+	defer span.End()
+
+	match, err := s.matchRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedMatchNotFound
+		}
+		return err
+	}
+
+	if !s.teamRepo.Exists(ctx, match.HomeTeamID) || !s.teamRepo.Exists(ctx, match.AwayTeamID) {
+		return ErrTeamDeleted.Withf("a team of this match is deleted, restore the team first")
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.goalRepo.RestoreByMatchID(ctx, id, match.DeletedAt.Time); err != nil {
+			return err
+		}
+		return s.matchRepo.Restore(ctx, id)
+	})
+}
+
+func (s *TrashService) PurgeTeam(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.PurgeTeam")
+	defer span.End()
+
+	team, err := s.teamRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedTeamNotFound
+		}
+		return err
+	}
+
+	if err := s.checkRetention(team.DeletedAt.Time); err != nil {
+		return err
+	}
+	if s.matchRepo.CountByTeamID(ctx, id) > 0 {
+		return ErrTeamReferenced
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.playerRepo.PurgeByTeamID(ctx, id); err != nil {
+			return err
+		}
+		return s.teamRepo.Purge(ctx, id)
+	})
+}
+
+func (s *TrashService) PurgePlayer(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.PurgePlayer")
+	defer span.End()
+
+	player, err := s.playerRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedPlayerNotFound
+		}
+		return err
+	}
+
+	if err := s.checkRetention(player.DeletedAt.Time); err != nil {
+		return err
+	}
+	if s.goalRepo.CountByPlayerID(ctx, id) > 0 {
+		return ErrPlayerReferenced
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.playerRepo.Purge(ctx, id)
+	})
+}
+
+func (s *TrashService) PurgeMatch(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "TrashService.PurgeMatch")
+	defer span.End()
+
+	match, err := s.matchRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDeletedMatchNotFound
+		}
+		return err
+	}
+
+	if err := s.checkRetention(match.DeletedAt.Time); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.goalRepo.PurgeByMatchID(ctx, id); err != nil {
+			return err
+		}
+		return s.matchRepo.Purge(ctx, id)
+	})
+}
+
+func (s *TrashService) checkRetention(deletedAt time.Time) error {
+	purgeableAt := deletedAt.Add(s.retention)
+	if time.Now().Before(purgeableAt) {
+		return ErrWithinRetention.Withf("record is within its retention period and can be purged after %s", purgeableAt.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pranotoism/football-go/dto"
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository"
+	"github.com/pranotoism/football-go/tracing"
+	"github.com/pranotoism/football-go/util"
+)
+
+const emailVerificationTTL = 24 * time.Hour
+
+type UserService struct {
+	tx       repository.Transactor
+	userRepo UserRepository
+	mailer   util.Mailer
+}
+
+func NewUserService(tx repository.Transactor, userRepo UserRepository, mailer util.Mailer) *UserService {
+	return &UserService{tx: tx, userRepo: userRepo, mailer: mailer}
+}
+
+func (s *UserService) GetProfile(ctx context.Context, id uint) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetProfile")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateProfile applies a name change immediately. A new email address is
+// only stored as pending until the owner confirms it with the token sent to
+// that address.
+func (s *UserService) UpdateProfile(ctx context.Context, id uint, req dto.UpdateProfileRequest) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
+	user, err := s.GetProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		user.Name = req.Name
+	}
+
+	var token string
+	if req.Email != "" && req.Email != user.Email {
+		existing, _ := s.userRepo.FindByEmail(ctx, req.Email)
+		if existing != nil {
+			return nil, ErrEmailTaken
+		}
+
+		token, err = generateVerificationToken()
+		if err != nil {
+			return nil, err
+		}
+
+		expiry := time.Now().Add(emailVerificationTTL)
+		user.PendingEmail = req.Email
+		user.EmailVerificationToken = hashVerificationToken(token)
+		user.EmailVerificationExpiry = &expiry
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		body := fmt.Sprintf("Use this token to confirm your new email address: %s\nThe token expires in %s.", token, emailVerificationTTL)
+		if err := s.mailer.Send(user.PendingEmail, "Confirm your new email address", body); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyEmail")
+	defer span.End()
+
+	user, err := s.userRepo.FindByEmailVerificationToken(ctx, hashVerificationToken(req.Token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+
+	if user.PendingEmail == "" || user.EmailVerificationExpiry == nil || time.Now().After(*user.EmailVerificationExpiry) {
+		return nil, ErrVerificationTokenExpired
+	}
+
+	existing, _ := s.userRepo.FindByEmail(ctx, user.PendingEmail)
+	if existing != nil && existing.ID != user.ID {
+		return nil, ErrEmailTaken
+	}
+
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	user.EmailVerificationToken = ""
+	user.EmailVerificationExpiry = nil
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteAccount soft-deletes the user and bumps the token version so every
+// token issued before the deletion is rejected. Both happen in one
+// transaction, so a failed deletion leaves the user signed in.
+func (s *UserService) DeleteAccount(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteAccount")
+	defer span.End()
+
+	user, err := s.GetProfile(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user.TokenVersion++
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.userRepo.Delete(ctx, user)
+	})
+}
+
+func generateVerificationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pranotoism/football-go/model"
+	"github.com/pranotoism/football-go/repository/memory"
+	"github.com/pranotoism/football-go/service"
+	"github.com/pranotoism/football-go/util"
+)
+
+// failingDelete is a user repository whose deletes fail.
+type failingDelete struct {
+	*memory.UserRepository
+}
+
+func (failingDelete) Delete(context.Context, *model.User) error {
+	return errors.New("connection reset")
+}
+
+func TestUserServiceDeleteAccountIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	user := &model.User{Name: "Rina", Email: "rina@example.com", Password: "x"}
+	if err := store.Users().Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+
+	users := service.NewUserService(store, failingDelete{store.Users()}, util.NewLogMailer())
+	if err := users.DeleteAccount(ctx, user.ID); err == nil {
+		t.Fatal("DeleteAccount succeeded although the delete failed")
+	}
+	stored, err := store.Users().FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.TokenVersion != user.TokenVersion {
+		t.Errorf("token version = %d after a failed deletion, want %d", stored.TokenVersion, user.TokenVersion)
+	}
+
+	users = service.NewUserService(store, store.Users(), util.NewLogMailer())
+	if err := users.DeleteAccount(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.GetProfile(ctx, user.ID); !errors.Is(err, service.ErrUserNotFound) {
+		t.Errorf("GetProfile() after deletion error = %v, want %v", err, service.ErrUserNotFound)
+	}
+}
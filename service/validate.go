@@ -0,0 +1,106 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pranotoism/football-go/model"
+)
+
+// validate checks the binding tags of request structs the way gin does for
+// request bodies, for input that arrives some other way, such as imports.
+var validate = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(FieldName)
+	v.RegisterValidation("kickoff_time", KickoffTime)
+	return v
+}()
+
+// KickoffTime validates the kickoff_time tag: a local time in one of
+// model.TimeLayouts.
+func KickoffTime(fl validator.FieldLevel) bool {
+	for _, layout := range model.TimeLayouts {
+		if _, err := time.Parse(layout, fl.Field().String()); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldName names a struct field by its json or form tag, so that errors
+// refer to fields by the name the client sent rather than the Go name.
+func FieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// FieldErrors describes validation errors field by field.
+func FieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		fields[i] = FieldError{
+			Field:   fieldPath(fe),
+			Code:    fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+	return fields
+}
+
+// fieldPath drops the struct name from the namespace, so that
+// "CreateMatchRequest.goals[0].minute" becomes "goals[0].minute".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "gte":
+		return "must be at least " + fe.Param() + unit
+	case "max", "lte":
+		return "must be at most " + fe.Param() + unit
+	case "gt":
+		return "must be greater than " + fe.Param() + unit
+	case "lt":
+		return "must be less than " + fe.Param() + unit
+	case "datetime":
+		return "must match the layout " + fe.Param()
+	case "kickoff_time":
+		return "must be an HH:MM or HH:MM:SS time"
+	case "timezone":
+		return "must be an IANA time zone such as Asia/Jakarta"
+	case "len":
+		return "must be exactly " + fe.Param() + unit
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+import "context"
+
+type contextKey string
+
+const (
+	actorIDKey   contextKey = "actorID"
+	requestIDKey contextKey = "requestID"
+)
+
+func WithActorID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorIDKey, userID)
+}
+
+func ActorIDFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(actorIDKey).(uint)
+	return userID, ok
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
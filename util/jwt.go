@@ -1,42 +1,202 @@
 package util
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret []byte
+var (
+	jwtSecret   []byte
+	signingKeys []SigningKey
+)
+
+// SigningKey is an asymmetric key used to sign tokens. A key becomes the
+// signing key once ActiveAt has passed, and stays valid for verification
+// for as long as it is configured.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+	ActiveAt   time.Time
+}
 
 func SetJWTSecret(secret string) {
 	jwtSecret = []byte(secret)
 }
 
+func SetSigningKeys(keys []SigningKey) {
+	sorted := make([]SigningKey, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveAt.Before(sorted[j].ActiveAt)
+	})
+	signingKeys = sorted
+}
+
+// LoadSigningKeys parses a comma-separated list of "kid=path[@RFC3339]"
+// entries, e.g. "2026-01=keys/a.pem,2026-07=keys/b.pem@2026-07-01T00:00:00Z".
+// Keys without an activation time are active immediately.
+func LoadSigningKeys(spec string) ([]SigningKey, error) {
+	var keys []SigningKey
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, rest, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || rest == "" {
+			return nil, fmt.Errorf("invalid JWT key entry %q, expected kid=path[@activation]", entry)
+		}
+		if seen[kid] {
+			return nil, fmt.Errorf("duplicate JWT key id %q", kid)
+		}
+		seen[kid] = true
+
+		path, activation, _ := strings.Cut(rest, "@")
+		var activeAt time.Time
+		if activation != "" {
+			t, err := time.Parse(time.RFC3339, activation)
+			if err != nil {
+				return nil, fmt.Errorf("invalid activation time for JWT key %q: %w", kid, err)
+			}
+			activeAt = t
+		}
+
+		key, err := LoadSigningKey(kid, path, activeAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func LoadSigningKey(kid, path string, activeAt time.Time) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read JWT key %q: %w", kid, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("JWT key %q is not PEM encoded", kid)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("JWT key %q has unsupported PEM type %q", kid, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse JWT key %q: %w", kid, err)
+	}
+
+	key := SigningKey{ID: kid, ActiveAt: activeAt}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PrivateKey = k
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PrivateKey = k
+	default:
+		return SigningKey{}, fmt.Errorf("JWT key %q must be an RSA or Ed25519 private key", kid)
+	}
+
+	return key, nil
+}
+
+func currentSigningKey(now time.Time) (*SigningKey, error) {
+	var current *SigningKey
+	for i := range signingKeys {
+		if !signingKeys[i].ActiveAt.After(now) {
+			current = &signingKeys[i]
+		}
+	}
+	if current == nil {
+		return nil, errors.New("no active JWT signing key")
+	}
+	return current, nil
+}
+
+func findSigningKey(kid string) *SigningKey {
+	for i := range signingKeys {
+		if signingKeys[i].ID == kid {
+			return &signingKeys[i]
+		}
+	}
+	return nil
+}
+
 type Claims struct {
-	UserID uint `json:"user_id"`
+	UserID       uint `json:"user_id"`
+	TokenVersion int  `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint) (string, error) {
+func GenerateToken(userID uint, tokenVersion int) (string, error) {
+	now := time.Now()
 	claims := Claims{
-		UserID: userID,
+		UserID:       userID,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(24 * time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	if len(signingKeys) == 0 {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(jwtSecret)
+	}
+
+	key, err := currentSigningKey(now)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if len(signingKeys) == 0 {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return jwtSecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key := findSigningKey(kid)
+		if key == nil {
+			return nil, errors.New("unknown signing key")
+		}
+		if token.Method.Alg() != key.Method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return jwtSecret, nil
+		return key.PrivateKey.Public(), nil
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,263 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeKeys writes an RSA and an Ed25519 private key to the test's
+// temporary directory and returns their paths.
+func writeKeys(t *testing.T) (rsaPath, edPath string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPath = filepath.Join(dir, "rsa.pem")
+	writePEM(t, rsaPath, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPath = filepath.Join(dir, "ed25519.pem")
+	writePEM(t, edPath, "PRIVATE KEY", der)
+	return rsaPath, edPath
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// useKeys installs keys for the duration of the test.
+func useKeys(t *testing.T, keys []SigningKey) {
+	t.Helper()
+	previous := signingKeys
+	SetSigningKeys(keys)
+	t.Cleanup(func() { signingKeys = previous })
+}
+
+func loadKeys(t *testing.T, spec string) []SigningKey {
+	t.Helper()
+	keys, err := LoadSigningKeys(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func tokenKID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	rsaPath, edPath := writeKeys(t)
+
+	keys := loadKeys(t, fmt.Sprintf(" old=%s , new=%s@2026-07-01T00:00:00Z", rsaPath, edPath))
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(keys))
+	}
+	if keys[0].ID != "old" || keys[0].Method != jwt.SigningMethodRS256 || !keys[0].ActiveAt.IsZero() {
+		t.Errorf("first key = %s %s %v, want old RS256 active immediately", keys[0].ID, keys[0].Method.Alg(), keys[0].ActiveAt)
+	}
+	if keys[1].ID != "new" || keys[1].Method != jwt.SigningMethodEdDSA || !keys[1].ActiveAt.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("second key = %s %s %v, want new EdDSA active from 2026-07-01", keys[1].ID, keys[1].Method.Alg(), keys[1].ActiveAt)
+	}
+
+	for _, spec := range []string{
+		"old",
+		"=" + rsaPath,
+		"old=" + rsaPath + ",old=" + edPath,
+		"old=" + rsaPath + "@tomorrow",
+		"old=" + filepath.Join(t.TempDir(), "missing.pem"),
+	} {
+		if _, err := LoadSigningKeys(spec); err == nil {
+			t.Errorf("LoadSigningKeys(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestSigningKeyRotation(t *testing.T) {
+	rsaPath, edPath := writeKeys(t)
+	now := time.Now()
+	past := now.Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := now.Add(time.Hour).UTC().Format(time.RFC3339)
+
+	// Signed before the rotation, so only the old key could have produced it
+	useKeys(t, loadKeys(t, "old="+rsaPath))
+	retired, err := GenerateToken(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantKID string
+	}{
+		{"newest active key signs", fmt.Sprintf("old=%s,new=%s@%s", rsaPath, edPath, past), "new"},
+		{"future key is not used yet", fmt.Sprintf("old=%s,new=%s@%s", rsaPath, edPath, future), "old"},
+		{"order of the spec does not matter", fmt.Sprintf("new=%s@%s,old=%s", edPath, past, rsaPath), "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useKeys(t, loadKeys(t, tt.spec))
+
+			token, err := GenerateToken(7, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid := tokenKID(t, token); kid != tt.wantKID {
+				t.Errorf("token signed with %q, want %q", kid, tt.wantKID)
+			}
+			claims, err := ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+			if claims.UserID != 7 || claims.TokenVersion != 3 {
+				t.Errorf("claims = user %d version %d, want user 7 version 3", claims.UserID, claims.TokenVersion)
+			}
+
+			if _, err := ValidateToken(retired); err != nil {
+				t.Errorf("token of the retired key was rejected: %v", err)
+			}
+		})
+	}
+
+	t.Run("no active key", func(t *testing.T) {
+		useKeys(t, loadKeys(t, fmt.Sprintf("new=%s@%s", edPath, future)))
+		if _, err := GenerateToken(1, 0); err == nil {
+			t.Error("GenerateToken succeeded before any key was active")
+		}
+	})
+}
+
+func TestValidateTokenRejects(t *testing.T) {
+	rsaPath, edPath := writeKeys(t)
+	keys := loadKeys(t, fmt.Sprintf("rsa=%s,ed=%s@%s", rsaPath, edPath, time.Now().Add(time.Hour).UTC().Format(time.RFC3339)))
+	useKeys(t, keys)
+
+	claims := Claims{UserID: 1, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+	sign := func(method jwt.SigningMethod, kid string, key any) string {
+		t.Helper()
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	// The classic algorithm confusion attack: HMAC keyed with the public key
+	publicDER, err := x509.MarshalPKIXPublicKey(keys[0].PrivateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	removed, _ := writeKeys(t)
+	removedKey := loadKeys(t, "rsa="+removed)[0]
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown kid", sign(jwt.SigningMethodRS256, "gone", keys[0].PrivateKey)},
+		{"missing kid", sign(jwt.SigningMethodRS256, "", keys[0].PrivateKey)},
+		{"HS256 against an RSA kid", sign(jwt.SigningMethodHS256, "rsa", publicPEM)},
+		{"HS256 keyed with the DER public key", sign(jwt.SigningMethodHS256, "rsa", publicDER)},
+		{"EdDSA against an RSA kid", sign(jwt.SigningMethodEdDSA, "rsa", keys[1].PrivateKey)},
+		{"signed by a key that is no longer configured", sign(jwt.SigningMethodRS256, "rsa", removedKey.PrivateKey)},
+		{"none algorithm", sign(jwt.SigningMethodNone, "rsa", jwt.UnsafeAllowNoneSignatureType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateToken(tt.token); err == nil {
+				t.Error("ValidateToken accepted the token")
+			}
+		})
+	}
+}
+
+func TestJWKSHasNoPrivateMaterial(t *testing.T) {
+	rsaPath, edPath := writeKeys(t)
+	keys := loadKeys(t, fmt.Sprintf("rsa=%s,ed=%s@2099-01-01T00:00:00Z", rsaPath, edPath))
+	useKeys(t, keys)
+
+	data, err := json.Marshal(JWKS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Keys) != 2 {
+		t.Fatalf("got %d keys, want both the active and the scheduled key", len(set.Keys))
+	}
+
+	public := map[string]bool{"kty": true, "kid": true, "use": true, "alg": true, "n": true, "e": true, "crv": true, "x": true}
+	for _, key := range set.Keys {
+		for field := range key {
+			if !public[field] {
+				t.Errorf("key %s exposes %q", key["kid"], field)
+			}
+		}
+	}
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	rsaKey := keys[0].PrivateKey.(*rsa.PrivateKey)
+	edKey := keys[1].PrivateKey.(ed25519.PrivateKey)
+	secrets := []string{
+		b64(rsaKey.D.Bytes()),
+		b64(rsaKey.Primes[0].Bytes()),
+		b64(rsaKey.Primes[1].Bytes()),
+		b64(edKey.Seed()),
+	}
+	for _, secret := range secrets {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("JWKS contains private key material: %s", data)
+		}
+	}
+	if set.Keys[0]["kty"] != "RSA" || set.Keys[0]["n"] != b64(rsaKey.N.Bytes()) || set.Keys[0]["e"] != "AQAB" {
+		t.Errorf("RSA key = %v, want its public modulus and exponent", set.Keys[0])
+	}
+	if set.Keys[1]["kty"] != "OKP" || set.Keys[1]["crv"] != "Ed25519" || set.Keys[1]["x"] != b64(edKey.Public().(ed25519.PublicKey)) {
+		t.Errorf("Ed25519 key = %v, want its public point", set.Keys[1])
+	}
+}
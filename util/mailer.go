@@ -0,0 +1,89 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// LogMailer writes outgoing mail to the application log, body included, so
+// that verification links can be followed in development. It stands in for
+// a real mail transport there and must not be used elsewhere, since bodies
+// carry verification tokens.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(to, subject, body string) error {
+	slog.Info("mail sent", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+// SMTPMailer delivers mail through an SMTP server, upgrading the connection
+// with STARTTLS when the server offers it. Credentials are only sent over
+// TLS or to localhost.
+type SMTPMailer struct {
+	addr string
+	from *mail.Address
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) (*SMTPMailer, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("parse sender %q: %w", from, err)
+	}
+
+	m := &SMTPMailer{addr: net.JoinHostPort(host, strconv.Itoa(port)), from: sender}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("parse recipient %q: %w", to, err)
+	}
+	if strings.ContainsAny(subject, "\r\n") {
+		return errors.New("mail subject must be a single line")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", recipient)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return err
+	}
+	if err := qp.Close(); err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from.Address, []string{recipient.Address}, msg.Bytes()); err != nil {
+		return fmt.Errorf("send mail to %s: %w", recipient.Address, err)
+	}
+	return nil
+}
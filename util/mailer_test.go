@@ -0,0 +1,126 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLogMailerWritesBody(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	if err := NewLogMailer().Send("a@example.com", "Confirm", "token: s3cret"); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "s3cret") || !strings.Contains(out, "a@example.com") {
+		t.Errorf("log = %q, want the recipient and the body", out)
+	}
+}
+
+// smtpServer accepts one SMTP session on a local port and sends the
+// envelope and message it received on the returned channel.
+func smtpServer(t *testing.T) (addr string, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+		var session strings.Builder
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				session.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil || data == ".\r\n" {
+						break
+					}
+					session.WriteString(data)
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				out <- session.String()
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), out
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	addr, received := smtpServer(t)
+	host, port, _ := net.SplitHostPort(addr)
+	portNumber, _ := strconv.Atoi(port)
+
+	mailer, err := NewSMTPMailer(host, portNumber, "", "", "Football Go <no-reply@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mailer.Send("fan@example.com", "Konfirmasi email", "Token: abc=123\nBerlaku 24 jam."); err != nil {
+		t.Fatal(err)
+	}
+
+	session := <-received
+	envelope, message, _ := strings.Cut(session, "From:")
+	if !strings.Contains(envelope, "MAIL FROM:<no-reply@example.com>") || !strings.Contains(envelope, "RCPT TO:<fan@example.com>") {
+		t.Errorf("envelope = %q", envelope)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader("From:" + message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := msg.Header.Get("To"); got != "<fan@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(quotedprintable.NewReader(msg.Body)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSuffix(body.String(), "\r\n"); got != "Token: abc=123\r\nBerlaku 24 jam." {
+		t.Errorf("body = %q", got)
+	}
+
+	for _, tt := range []struct{ to, subject string }{
+		{"fan@example.com", "Hi\r\nBcc: victim@example.com"},
+		{"fan@example.com\r\nBcc: victim@example.com", "Hi"},
+	} {
+		if err := mailer.Send(tt.to, tt.subject, "body"); err == nil {
+			t.Errorf("Send(%q, %q) succeeded, want a header injection error", tt.to, tt.subject)
+		}
+	}
+}
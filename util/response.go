@@ -1,6 +1,20 @@
 package util
 
-import "github.com/gin-gonic/gin"
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces the problem type URIs; the error code
+// completes them.
+const problemTypePrefix = "urn:football-go:problem:"
 
 type Response struct {
 	Status  string      `json:"status"`
@@ -8,6 +22,19 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// Problem is an RFC 7807 problem details object, extended with the error
+// code, the request ID and, for invalid requests, the offending fields.
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	Code      string      `json:"code"`
+	RequestID string      `json:"request_id,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+}
+
 type PaginatedResponse struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message"`
@@ -15,11 +42,31 @@ type PaginatedResponse struct {
 	Meta    Meta        `json:"meta"`
 }
 
+// Meta describes a page of a list. Page and TotalPages are left out of
+// pages fetched by cursor, and Total when the list was not counted.
 type Meta struct {
-	Page       int   `json:"page"`
-	PerPage    int   `json:"per_page"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	Total      *int64 `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
+// PageMeta describes page of a list of total items.
+func PageMeta(page, perPage int, total int64) Meta {
+	meta := Meta{Page: page, PerPage: perPage}
+	meta.SetTotal(&total)
+	return meta
+}
+
+// SetTotal records the item count, if known, and the page count it implies.
+func (m *Meta) SetTotal(total *int64) {
+	if total == nil {
+		return
+	}
+	pages := int(math.Ceil(float64(*total) / float64(m.PerPage)))
+	m.Total, m.TotalPages = total, &pages
 }
 
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
@@ -30,11 +77,36 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
+// ErrorResponse writes a problem whose code is derived from the status, for
+// errors that have no more specific code, such as a missing token.
 func ErrorResponse(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, Response{
-		Status:  "error",
-		Message: message,
-	})
+	ProblemResponse(c, statusCode, StatusCode(statusCode), message, nil)
+}
+
+// ProblemResponse writes a problem+json response. It includes the request ID
+// so that a client reporting an error can point at the matching log
+// records. fieldErrors is omitted when nil.
+func ProblemResponse(c *gin.Context, statusCode int, code, detail string, fieldErrors interface{}) {
+	c.Render(statusCode, problemRender{Problem{
+		Type:      problemTypePrefix + code,
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: RequestIDFromContext(c.Request.Context()),
+		Errors:    fieldErrors,
+	}})
+}
+
+// StatusCode turns a status into an error code, e.g. 429 into
+// "too_many_requests".
+func StatusCode(statusCode int) string {
+	text := strings.ToLower(http.StatusText(statusCode))
+	if text == "" {
+		return "error"
+	}
+	return strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
 }
 
 func PaginatedSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}, meta Meta) {
@@ -45,3 +117,17 @@ func PaginatedSuccessResponse(c *gin.Context, statusCode int, message string, da
 		Meta:    meta,
 	})
 }
+
+// problemRender is gin's JSON render with the problem+json content type.
+type problemRender struct {
+	problem Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.problem)
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemContentType)
+}
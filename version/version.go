@@ -0,0 +1,38 @@
+// Package version describes the running build. Commit and BuildTime are
+// meant to be set at link time:
+//
+//	go build -ldflags "-X github.com/pranotoism/football-go/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/pranotoism/football-go/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Without -ldflags the commit recorded by the Go toolchain is used when
+// available.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+const unknown = "unknown"
+
+var (
+	Commit    = ""
+	BuildTime = unknown
+)
+
+// GoVersion is the Go release the binary was built with.
+var GoVersion = runtime.Version()
+
+func init() {
+	if Commit != "" {
+		return
+	}
+	Commit = unknown
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				Commit = setting.Value
+			}
+		}
+	}
+}